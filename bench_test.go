@@ -0,0 +1,76 @@
+package simplexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+var benchInput = strings.Repeat("hello_world = \"hello world\"\nnumber = 1.5 + foo(bar, 42)\n", 1000)
+
+func benchmarkLexer(b *testing.B, newLexer func() *simplexer.Lexer) {
+	b.SetBytes(int64(len(benchInput)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		lexer := newLexer()
+		for {
+			token, err := lexer.Scan()
+			if err != nil {
+				b.Fatalf("unexcepted error: %s", err)
+			}
+			if token == nil {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkLexer_reader(b *testing.B) {
+	benchmarkLexer(b, func() *simplexer.Lexer {
+		return simplexer.NewLexer(strings.NewReader(benchInput))
+	})
+}
+
+func BenchmarkLexer_string(b *testing.B) {
+	benchmarkLexer(b, func() *simplexer.Lexer {
+		return simplexer.NewLexerString(benchInput)
+	})
+}
+
+func BenchmarkLexer_bytes(b *testing.B) {
+	input := []byte(benchInput)
+
+	benchmarkLexer(b, func() *simplexer.Lexer {
+		return simplexer.NewLexerBytes(input)
+	})
+}
+
+// benchTokenTypes is a token list like a real language, that has more TokenTypes than DefaultTokenTypes.
+var benchTokenTypes = append([]simplexer.TokenType{
+	simplexer.NewPatternTokenType(100, []string{"==", "!=", "<=", ">=", "&&", "||"}),
+	simplexer.NewPatternTokenType(101, []string{"(", ")", "{", "}", "[", "]", ",", ";"}),
+	simplexer.NewRegexpTokenType(102, `(if|else|for|return|func)\b`),
+	simplexer.NewRegexpTokenType(103, `0x[0-9a-fA-F]+`),
+	simplexer.NewRegexpTokenType(104, `'(\\.|[^'\\])'`),
+	simplexer.NewRegexpTokenType(105, `[+\-*/%=<>!]`),
+}, simplexer.DefaultTokenTypes...)
+
+func BenchmarkLexer_sequential(b *testing.B) {
+	benchmarkLexer(b, func() *simplexer.Lexer {
+		lexer := simplexer.NewLexerString(benchInput)
+		lexer.TokenTypes = benchTokenTypes
+		return lexer
+	})
+}
+
+func BenchmarkLexer_compiled(b *testing.B) {
+	compiled := []simplexer.TokenType{simplexer.NewCompiledTokenType(benchTokenTypes)}
+
+	benchmarkLexer(b, func() *simplexer.Lexer {
+		lexer := simplexer.NewLexerString(benchInput)
+		lexer.TokenTypes = compiled
+		return lexer
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Command simplexer-gen generates a table-driven lexer from a spec file of simplexer.
+
+	simplexer-gen -spec lexer.json -package mylang -o lexer_gen.go
+
+The spec file is JSON of simplexer.Spec.
+The generated source doesn't depend on simplexer, and has Lexer, Token and Position like simplexer.
+Please use it with go:generate, like below.
+
+	//go:generate simplexer-gen -spec lexer.json -package mylang -o lexer_gen.go
+
+Lexers that declared by Go code can not be generated, because TokenTypes in Go code can not be read by a command.
+Please write the rules as a spec file, and use Spec.NewLexer for the interpreted Lexer.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/macrat/simplexer"
+	"github.com/macrat/simplexer/internal/gen"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the spec file in JSON. read from stdin if empty.")
+	pkg := flag.String("package", "main", "package name of the generated source.")
+	output := flag.String("o", "", "path to output. write to stdout if empty.")
+	flag.Parse()
+
+	if err := run(*specPath, *pkg, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "simplexer-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, pkg, output string) error {
+	var data []byte
+	var err error
+	if specPath == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(specPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	spec, err := simplexer.ParseSpec(data)
+	if err != nil {
+		if specPath != "" {
+			return fmt.Errorf("%s:%w", specPath, err)
+		}
+		return err
+	}
+
+	src, err := gen.Generate(spec, pkg)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
+}
@@ -0,0 +1,194 @@
+/*
+Command simplexer prints tokens of input files for inspecting a spec file of simplexer.
+
+	simplexer -spec lexer.json [flags] [files...]
+
+The spec file is JSON of simplexer.Spec. Input is read from stdin if no files are given.
+
+Tokens are printed with positions, IDs, names, literals and submatches.
+Line and column are 0-based like simplexer.Position, and column is counted in bytes.
+
+Flags:
+
+	-format table|jsonl|csv
+		output format. the default is table.
+	-whitespace
+		print whitespaces as WHITESPACE tokens.
+	-stop
+		stop at the first error. the default is printing unknown tokens as ERROR tokens and continuing.
+
+Errors are printed to stderr.
+The exit status is 1 if found errors like unknown tokens or failed to open files, and 2 if the spec or flags are invalid.
+Files after a file that failed to open are still printed.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/macrat/simplexer"
+)
+
+type options struct {
+	spec       *simplexer.Spec
+	registry   *simplexer.TokenRegistry
+	whitespace bool
+	stop       bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("simplexer", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	specPath := flags.String("spec", "", "path to the spec file in JSON. (required)")
+	format := flags.String("format", "table", "output format. table, jsonl or csv.")
+	whitespace := flags.Bool("whitespace", false, "print whitespaces as WHITESPACE tokens.")
+	stop := flags.Bool("stop", false, "stop at the first error.")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *specPath == "" {
+		fmt.Fprintln(stderr, "simplexer: -spec is required")
+		return 2
+	}
+
+	w, err := newTokenWriter(*format, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "simplexer: %s\n", err)
+		return 2
+	}
+
+	opts, err := loadOptions(*specPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "simplexer: %s\n", err)
+		return 2
+	}
+	opts.whitespace = *whitespace
+	opts.stop = *stop
+
+	status := 0
+
+	if flags.NArg() == 0 {
+		status = opts.tokenize("<stdin>", stdin, w, stderr)
+	} else {
+		for _, name := range flags.Args() {
+			f, err := os.Open(name)
+			if err != nil {
+				fmt.Fprintf(stderr, "simplexer: %s\n", err)
+				status = 1
+				if opts.stop {
+					break
+				}
+				continue
+			}
+
+			s := opts.tokenize(name, f, w, stderr)
+			f.Close()
+
+			if s != 0 {
+				status = s
+				if opts.stop {
+					break
+				}
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "simplexer: %s\n", err)
+		return 1
+	}
+
+	return status
+}
+
+func loadOptions(path string) (*options, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := simplexer.ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", path, err)
+	}
+
+	registry, err := spec.Registry()
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", path, err)
+	}
+
+	return &options{spec: spec, registry: registry}, nil
+}
+
+// tokenize prints tokens in reader, and returns the exit status.
+func (o *options) tokenize(name string, reader io.Reader, w tokenWriter, stderr io.Writer) int {
+	lexer, err := o.spec.NewLexer(reader)
+	if err != nil {
+		fmt.Fprintf(stderr, "simplexer: %s\n", err)
+		return 2
+	}
+
+	lexer.Lossless = o.whitespace
+	if !o.stop {
+		lexer.Recovery = simplexer.EmitErrorToken
+	}
+
+	renderer := simplexer.DiagnosticRenderer{
+		Filename: name,
+		Registry: o.registry,
+	}
+
+	for token, err := range lexer.All() {
+		if err != nil {
+			fmt.Fprint(stderr, renderer.Render(err))
+			return 1
+		}
+
+		if err := o.write(w, name, token); err != nil {
+			fmt.Fprintf(stderr, "simplexer: %s\n", err)
+			return 1
+		}
+
+		if token.Err != nil {
+			fmt.Fprint(stderr, renderer.Render(token.Err))
+		}
+	}
+
+	if len(lexer.Errors()) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (o *options) write(w tokenWriter, name string, token *simplexer.Token) error {
+	for _, t := range token.LeadingTrivia {
+		if err := w.Write(name, "WHITESPACE", t); err != nil {
+			return err
+		}
+	}
+
+	// EOF token has only trivia of input without tokens, so it is not printed.
+	if token.Type.GetID() != simplexer.EOF {
+		if err := w.Write(name, o.registry.String(token.Type.GetID()), token); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range token.TrailingTrivia {
+		if err := w.Write(name, "WHITESPACE", t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"whitespace": {"patterns": [" ", "\n"]},
+	"tokens": [
+		{"name": "NUM", "id": 1, "regexp": "[0-9]+"},
+		{"name": "PAIR", "id": 2, "regexp": "([a-z]+)=([a-z]+)"},
+		{"name": "WORD", "id": 3, "regexp": "[a-z]+"}
+	]
+}`
+
+func writeSpec(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(path, []byte(testSpec), 0644); err != nil {
+		t.Fatalf("failed to write spec: %s", err)
+	}
+	return path
+}
+
+func runCommand(args []string, input string) (status int, stdout, stderr string) {
+	var out, errOut strings.Builder
+	status = run(args, strings.NewReader(input), &out, &errOut)
+	return status, out.String(), errOut.String()
+}
+
+func TestRun_formats(t *testing.T) {
+	spec := writeSpec(t)
+
+	tests := []struct {
+		Args   []string
+		Output string
+	}{
+		{
+			[]string{"-spec", spec},
+			"FILE     POSITION  ID  NAME  LITERAL  SUBMATCHES\n" +
+				"<stdin>  0:0       1   NUM   \"12\"     \n" +
+				"<stdin>  0:3       2   PAIR  \"a=b\"    \"a\" \"b\"\n",
+		},
+		{
+			[]string{"-spec", spec, "-format", "jsonl"},
+			`{"file":"<stdin>","line":0,"column":0,"end_line":0,"end_column":2,"offset":0,"end_offset":2,"id":1,"name":"NUM","literal":"12"}` + "\n" +
+				`{"file":"<stdin>","line":0,"column":3,"end_line":0,"end_column":6,"offset":3,"end_offset":6,"id":2,"name":"PAIR","literal":"a=b","submatches":["a","b"]}` + "\n",
+		},
+		{
+			[]string{"-spec", spec, "-format", "csv"},
+			"file,line,column,end_line,end_column,offset,end_offset,id,name,literal,submatches,error\n" +
+				"<stdin>,0,0,0,2,0,2,1,NUM,12,,\n" +
+				"<stdin>,0,3,0,6,3,6,2,PAIR,a=b,\"[\"\"a\"\",\"\"b\"\"]\",\n",
+		},
+		{
+			[]string{"-spec", spec, "-format", "csv", "-whitespace"},
+			"file,line,column,end_line,end_column,offset,end_offset,id,name,literal,submatches,error\n" +
+				"<stdin>,0,0,0,2,0,2,1,NUM,12,,\n" +
+				"<stdin>,0,2,0,3,2,3,0,WHITESPACE,\" \",,\n" +
+				"<stdin>,0,3,0,6,3,6,2,PAIR,a=b,\"[\"\"a\"\",\"\"b\"\"]\",\n" +
+				"<stdin>,0,6,1,0,6,7,0,WHITESPACE,\"\n\",,\n",
+		},
+	}
+
+	for _, tc := range tests {
+		status, stdout, stderr := runCommand(tc.Args, "12 a=b\n")
+		if status != 0 {
+			t.Errorf("%v: excepted status 0 but got %d: %s", tc.Args, status, stderr)
+		}
+		if stdout != tc.Output {
+			t.Errorf("%v: excepted output\n%s\nbut got\n%s", tc.Args, tc.Output, stdout)
+		}
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	spec := writeSpec(t)
+	input := "1 ? 2"
+
+	status, stdout, stderr := runCommand([]string{"-spec", spec, "-format", "csv"}, input)
+	if status != 1 {
+		t.Errorf("excepted status 1 but got %d", status)
+	}
+	if !strings.Contains(stdout, "<stdin>,0,2,0,3,2,3,-5,ERROR,?,,\"1:3:UnknownTokenError: \"\"?\"\"\"\n<stdin>,0,4,") {
+		t.Errorf("excepted ERROR token and following token but got\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "error: unknown token \"?\"") {
+		t.Errorf("excepted diagnostic but got %#v", stderr)
+	}
+
+	status, stdout, stderr = runCommand([]string{"-spec", spec, "-format", "jsonl", "-stop"}, input)
+	if status != 1 {
+		t.Errorf("-stop: excepted status 1 but got %d", status)
+	}
+	if strings.Count(stdout, "\n") != 1 {
+		t.Errorf("-stop: excepted only 1 token but got\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "--> <stdin>:1:3") {
+		t.Errorf("-stop: excepted diagnostic but got %#v", stderr)
+	}
+}
+
+func TestRun_files(t *testing.T) {
+	spec := writeSpec(t)
+	dir := t.TempDir()
+
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	os.WriteFile(a, []byte("x"), 0644)
+	os.WriteFile(b, []byte("\n\n42"), 0644)
+
+	status, stdout, stderr := runCommand([]string{"-spec", spec, "-format", "csv", a, b}, "")
+	if status != 0 {
+		t.Fatalf("excepted status 0 but got %d: %s", status, stderr)
+	}
+
+	except := "file,line,column,end_line,end_column,offset,end_offset,id,name,literal,submatches,error\n" +
+		a + ",0,0,0,1,0,1,3,WORD,x,,\n" +
+		b + ",2,0,2,2,2,4,1,NUM,42,,\n"
+	if stdout != except {
+		t.Errorf("excepted\n%s\nbut got\n%s", except, stdout)
+	}
+
+	missing := filepath.Join(dir, "missing.txt")
+	status, stdout, stderr = runCommand([]string{"-spec", spec, "-format", "csv", a, missing, b}, "")
+	if status != 1 || !strings.Contains(stderr, missing) {
+		t.Errorf("excepted status 1 with error but got %d, %#v", status, stderr)
+	}
+	if stdout != except {
+		t.Errorf("excepted files after the missing file\n%s\nbut got\n%s", except, stdout)
+	}
+}
+
+func TestRun_whitespaceOnly(t *testing.T) {
+	spec := writeSpec(t)
+
+	status, stdout, stderr := runCommand([]string{"-spec", spec, "-format", "csv", "-whitespace"}, " \n")
+	if status != 0 {
+		t.Fatalf("excepted status 0 but got %d: %s", status, stderr)
+	}
+
+	except := "file,line,column,end_line,end_column,offset,end_offset,id,name,literal,submatches,error\n" +
+		"<stdin>,0,0,0,1,0,1,0,WHITESPACE,\" \",,\n" +
+		"<stdin>,0,1,1,0,1,2,0,WHITESPACE,\"\n\",,\n"
+	if stdout != except {
+		t.Errorf("excepted\n%s\nbut got\n%s", except, stdout)
+	}
+}
+
+func TestRun_invalid(t *testing.T) {
+	spec := writeSpec(t)
+
+	tests := []struct {
+		Args  []string
+		Error string
+	}{
+		{[]string{}, "simplexer: -spec is required\n"},
+		{[]string{"-spec", spec, "-format", "xml"}, "simplexer: unknown format \"xml\"\n"},
+	}
+
+	for _, tc := range tests {
+		status, _, stderr := runCommand(tc.Args, "")
+		if status != 2 {
+			t.Errorf("%v: excepted status 2 but got %d", tc.Args, status)
+		}
+		if stderr != tc.Error {
+			t.Errorf("%v: excepted error %#v but got %#v", tc.Args, tc.Error, stderr)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/macrat/simplexer"
+)
+
+// tokenWriter writes tokens in an output format.
+type tokenWriter interface {
+	Write(file, name string, t *simplexer.Token) error
+	Flush() error
+}
+
+func newTokenWriter(format string, w io.Writer) (tokenWriter, error) {
+	switch format {
+	case "table":
+		return newTableWriter(w), nil
+	case "jsonl":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &jsonlWriter{enc: enc}, nil
+	case "csv":
+		return newCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown format %#v", format)
+	}
+}
+
+func errorString(t *simplexer.Token) string {
+	if t.Err == nil {
+		return ""
+	}
+	return t.Err.Error()
+}
+
+// tableWriter writes tokens as a table for human.
+type tableWriter struct {
+	w *tabwriter.Writer
+}
+
+func newTableWriter(w io.Writer) *tableWriter {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tPOSITION\tID\tNAME\tLITERAL\tSUBMATCHES")
+	return &tableWriter{w: tw}
+}
+
+func (tw *tableWriter) Write(file, name string, t *simplexer.Token) error {
+	submatches := make([]string, len(t.Submatches))
+	for i, s := range t.Submatches {
+		submatches[i] = strconv.Quote(s)
+	}
+
+	_, err := fmt.Fprintf(tw.w, "%s\t%d:%d\t%d\t%s\t%s\t%s\n",
+		file,
+		t.Position.Line,
+		t.Position.Column,
+		t.Type.GetID(),
+		name,
+		strconv.Quote(t.Literal),
+		strings.Join(submatches, " "))
+	return err
+}
+
+func (tw *tableWriter) Flush() error {
+	return tw.w.Flush()
+}
+
+// jsonlWriter writes tokens as JSON Lines.
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+type jsonToken struct {
+	File       string   `json:"file"`
+	Line       int      `json:"line"`
+	Column     int      `json:"column"`
+	EndLine    int      `json:"end_line"`
+	EndColumn  int      `json:"end_column"`
+	Offset     int      `json:"offset"`
+	EndOffset  int      `json:"end_offset"`
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Literal    string   `json:"literal"`
+	Submatches []string `json:"submatches,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func (jw *jsonlWriter) Write(file, name string, t *simplexer.Token) error {
+	return jw.enc.Encode(jsonToken{
+		File:       file,
+		Line:       t.Position.Line,
+		Column:     t.Position.Column,
+		EndLine:    t.End.Line,
+		EndColumn:  t.End.Column,
+		Offset:     t.Offset,
+		EndOffset:  t.EndOffset,
+		ID:         int(t.Type.GetID()),
+		Name:       name,
+		Literal:    t.Literal,
+		Submatches: t.Submatches,
+		Error:      errorString(t),
+	})
+}
+
+func (jw *jsonlWriter) Flush() error {
+	return nil
+}
+
+/*
+csvWriter writes tokens as CSV with a header.
+
+Submatches are encoded as a JSON array in a column, because the number of them is different for each token.
+*/
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"file", "line", "column", "end_line", "end_column", "offset", "end_offset", "id", "name", "literal", "submatches", "error"})
+	return &csvWriter{w: cw}
+}
+
+func (cw *csvWriter) Write(file, name string, t *simplexer.Token) error {
+	submatches := ""
+	if len(t.Submatches) > 0 {
+		b, err := json.Marshal(t.Submatches)
+		if err != nil {
+			return err
+		}
+		submatches = string(b)
+	}
+
+	return cw.w.Write([]string{
+		file,
+		strconv.Itoa(t.Position.Line),
+		strconv.Itoa(t.Position.Column),
+		strconv.Itoa(t.End.Line),
+		strconv.Itoa(t.End.Column),
+		strconv.Itoa(t.Offset),
+		strconv.Itoa(t.EndOffset),
+		strconv.Itoa(int(t.Type.GetID())),
+		name,
+		t.Literal,
+		submatches,
+		errorString(t),
+	})
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,154 @@
+package simplexer
+
+import (
+	"strings"
+)
+
+/*
+LineCommentTokenType is a TokenType for comments until the end of line, like "// comment".
+
+ID is TokenID for this token type.
+
+Prefix is the start of comment, like "//" or "#".
+The comment doesn't include the newline at the end.
+
+If Trivia is true, Lexer skips comments like whitespaces instead of returning them as tokens.
+
+Found Token has the text after Prefix as Token.Submatches[0].
+*/
+type LineCommentTokenType struct {
+	ID     TokenID
+	Prefix string
+	Trivia bool
+}
+
+/*
+Make new LineCommentTokenType.
+
+id is a TokenID of new LineCommentTokenType.
+
+prefix is the start of comment.
+*/
+func NewLineCommentTokenType(id TokenID, prefix string) *LineCommentTokenType {
+	return &LineCommentTokenType{
+		ID:     id,
+		Prefix: prefix,
+	}
+}
+
+// Get readable string of TokenID.
+func (ltt *LineCommentTokenType) String() string {
+	return ltt.ID.String()
+}
+
+// GetID returns id of this token type.
+func (ltt *LineCommentTokenType) GetID() TokenID {
+	return ltt.ID
+}
+
+// FindToken returns new Token if s starts with Prefix.
+func (ltt *LineCommentTokenType) FindToken(s string, p Position) *Token {
+	if ltt.Prefix == "" || !strings.HasPrefix(s, ltt.Prefix) {
+		return nil
+	}
+
+	end := strings.IndexByte(s, '\n')
+	if end < 0 {
+		end = len(s)
+	}
+
+	return &Token{
+		Type:       ltt,
+		Literal:    s[:end],
+		Submatches: []string{s[len(ltt.Prefix):end]},
+		Position:   p,
+		trivia:     ltt.Trivia,
+	}
+}
+
+/*
+BlockCommentTokenType is a TokenType for comments between delimiters, like block comments of C.
+
+ID is TokenID for this token type.
+
+Open and Close are the delimiters of comment.
+
+If Nested is true, Open in a comment starts an inner comment, and the comment ends when all of them closed.
+Rust, Swift and Haskell use nested comments.
+
+If Trivia is true, Lexer skips comments like whitespaces instead of returning them as tokens.
+
+Found Token has the text between delimiters as Token.Submatches[0].
+If the comment is not closed, the Token has UnterminatedCommentError as Token.Err.
+*/
+type BlockCommentTokenType struct {
+	ID     TokenID
+	Open   string
+	Close  string
+	Nested bool
+	Trivia bool
+}
+
+/*
+Make new BlockCommentTokenType.
+
+id is a TokenID of new BlockCommentTokenType.
+
+open and close are the delimiters of comment.
+
+nested is whether comments can be nested.
+*/
+func NewBlockCommentTokenType(id TokenID, open, close string, nested bool) *BlockCommentTokenType {
+	return &BlockCommentTokenType{
+		ID:     id,
+		Open:   open,
+		Close:  close,
+		Nested: nested,
+	}
+}
+
+// Get readable string of TokenID.
+func (btt *BlockCommentTokenType) String() string {
+	return btt.ID.String()
+}
+
+// GetID returns id of this token type.
+func (btt *BlockCommentTokenType) GetID() TokenID {
+	return btt.ID
+}
+
+// FindToken returns new Token if s starts with Open.
+func (btt *BlockCommentTokenType) FindToken(s string, p Position) *Token {
+	if btt.Open == "" || btt.Close == "" || !strings.HasPrefix(s, btt.Open) {
+		return nil
+	}
+
+	depth := 1
+	for i := len(btt.Open); i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], btt.Close):
+			i += len(btt.Close)
+			if depth--; depth == 0 {
+				return &Token{
+					Type:       btt,
+					Literal:    s[:i],
+					Submatches: []string{s[len(btt.Open) : i-len(btt.Close)]},
+					Position:   p,
+					trivia:     btt.Trivia,
+				}
+			}
+		case btt.Nested && strings.HasPrefix(s[i:], btt.Open):
+			i += len(btt.Open)
+			depth++
+		default:
+			i++
+		}
+	}
+
+	return &Token{
+		Type:     btt,
+		Literal:  s,
+		Position: p,
+		Err:      UnterminatedCommentError{Position: p},
+	}
+}
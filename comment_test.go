@@ -0,0 +1,145 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewBlockCommentTokenType() {
+	lexer := simplexer.NewLexer(strings.NewReader("a /* x /* y */ z */ b // end"))
+
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewBlockCommentTokenType(simplexer.COMMENT, "/*", "*/", true),
+		simplexer.NewLineCommentTokenType(simplexer.COMMENT, "//"),
+	}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%s: %#v\n", token.Type.GetID(), token.Literal)
+	}
+
+	// Output:
+	// IDENT: "a"
+	// COMMENT: "/* x /* y */ z */"
+	// IDENT: "b"
+	// COMMENT: "// end"
+}
+
+func TestLineCommentTokenType(t *testing.T) {
+	ltt := simplexer.NewLineCommentTokenType(simplexer.COMMENT, "#")
+
+	tests := []struct {
+		Input   string
+		Literal string
+		Content string
+	}{
+		{"# hello\nworld", "# hello", " hello"},
+		{"#", "#", ""},
+		{"#a#b", "#a#b", "a#b"},
+	}
+
+	for _, tc := range tests {
+		tok := ltt.FindToken(tc.Input, simplexer.Position{})
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Submatches[0] != tc.Content {
+			t.Errorf("%#v: excepted content %#v but got %#v", tc.Input, tc.Content, tok.Submatches[0])
+		}
+	}
+
+	if tok := ltt.FindToken("a # b", simplexer.Position{}); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+}
+
+func TestBlockCommentTokenType(t *testing.T) {
+	tests := []struct {
+		Type    *simplexer.BlockCommentTokenType
+		Input   string
+		Literal string
+		Content string
+	}{
+		{simplexer.NewBlockCommentTokenType(0, "/*", "*/", false), "/* a */ b */", "/* a */", " a "},
+		{simplexer.NewBlockCommentTokenType(0, "/*", "*/", false), "/* /* a */ b */", "/* /* a */", " /* a "},
+		{simplexer.NewBlockCommentTokenType(0, "/*", "*/", true), "/* /* a */ b */ c", "/* /* a */ b */", " /* a */ b "},
+		{simplexer.NewBlockCommentTokenType(0, "/*", "*/", true), "/**/", "/**/", ""},
+		{simplexer.NewBlockCommentTokenType(0, "{-", "-}", true), "{- {- -} {--} -}-}", "{- {- -} {--} -}", " {- -} {--} "},
+		{simplexer.NewBlockCommentTokenType(0, "(*", "*)", true), "(*)*)", "(*)*)", ")"},
+	}
+
+	for _, tc := range tests {
+		tok := tc.Type.FindToken(tc.Input, simplexer.Position{})
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Err != nil {
+			t.Errorf("%#v: unexcepted error: %s", tc.Input, tok.Err)
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Submatches[0] != tc.Content {
+			t.Errorf("%#v: excepted content %#v but got %#v", tc.Input, tc.Content, tok.Submatches[0])
+		}
+	}
+}
+
+func TestLexer_unterminatedComment(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a\n  /* b /* c */\nd"))
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewBlockCommentTokenType(simplexer.COMMENT, "/*", "*/", true),
+	}, lexer.TokenTypes...)
+
+	tokens, err := lexer.Tokens()
+	if s := literals(tokens); s != "a" {
+		t.Errorf("excepted \"a\" but got %#v", s)
+	}
+
+	except := simplexer.UnterminatedCommentError{Position: simplexer.Position{Line: 1, Column: 2}}
+	if err != except {
+		t.Errorf("excepted %#v but got %#v", except, err)
+	}
+}
+
+func TestLexer_triviaComment(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a // x\n/* y */ b /* z */"))
+
+	line := simplexer.NewLineCommentTokenType(simplexer.COMMENT, "//")
+	line.Trivia = true
+	block := simplexer.NewBlockCommentTokenType(simplexer.COMMENT, "/*", "*/", false)
+	block.Trivia = true
+
+	lexer.TokenTypes = append([]simplexer.TokenType{line, block}, lexer.TokenTypes...)
+
+	tokens, err := lexer.Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	if s := literals(tokens); s != "a,b" {
+		t.Errorf("excepted \"a,b\" but got %#v", s)
+	}
+
+	except := simplexer.Position{Line: 1, Column: 8}
+	if tokens[1].Position != except {
+		t.Errorf("excepted %s but got %s", except, tokens[1].Position)
+	}
+}
@@ -0,0 +1,291 @@
+package simplexer
+
+import (
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/macrat/simplexer/internal/dfa"
+)
+
+/*
+CompiledTokenType is a TokenType that merges some TokenTypes into a single DFA.
+
+Lexer runs FindToken of all TokenTypes in order until one of them matched,
+so it runs a regular expression for each RegexpTokenType at each token.
+CompiledTokenType merges consecutive RegexpTokenTypes and PatternTokenTypes into one DFA,
+that finds the matched TokenType and the length of the token in one pass.
+The regular expression of the matched TokenType runs again only if it has submatches.
+
+Other TokenTypes are checked by their own FindToken in the order.
+So are regular expressions that can't be merged:
+ones that aren't anchored by "^", that can match the empty string, that use other anchors or word boundaries,
+and ones that compiled by regexp.CompilePOSIX or made longest by Regexp.Longest.
+
+Found token is always the same as the token that found by original TokenTypes.
+Token.Type of found token is the original TokenType, not CompiledTokenType.
+
+Lexer.Strategy is respected. If it is LongestMatch, Lexer selects the longest token of all TokenTypes.
+*/
+type CompiledTokenType struct {
+	TokenTypes []TokenType
+	segments   []compiledSegment
+}
+
+// compiledSegment is TokenTypes that merged into a DFA, or a TokenType that checked by its own FindToken.
+type compiledSegment struct {
+	dfa   *dfa.DFA
+	rules []TokenType
+	other TokenType
+}
+
+/*
+Make new CompiledTokenType.
+
+types is an array of TokenType to compile.
+Usually, it is Lexer.TokenTypes.
+
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewCompiledTokenType(lexer.TokenTypes),
+	}
+*/
+func NewCompiledTokenType(types []TokenType) *CompiledTokenType {
+	ctt := &CompiledTokenType{
+		TokenTypes: types,
+	}
+
+	var rules []*syntax.Regexp
+	var merged []TokenType
+
+	flush := func() {
+		if len(rules) == 0 {
+			return
+		}
+
+		d, err := dfa.Build(rules)
+		if err != nil {
+			// It never happens, because compileRule already built each rule.
+			panic(err)
+		}
+		ctt.segments = append(ctt.segments, compiledSegment{dfa: d, rules: merged})
+		rules, merged = nil, nil
+	}
+
+	for _, tokenType := range types {
+		if re, ok := compileRule(tokenType); ok {
+			rules = append(rules, re)
+			merged = append(merged, tokenType)
+			continue
+		}
+
+		flush()
+		ctt.segments = append(ctt.segments, compiledSegment{other: tokenType})
+	}
+	flush()
+
+	return ctt
+}
+
+// compileRule makes a rule of DFA that matches the same string as tt, or returns false if tt can't be merged.
+func compileRule(tt TokenType) (*syntax.Regexp, bool) {
+	var re *syntax.Regexp
+
+	switch tt := tt.(type) {
+	case *RegexpTokenType:
+		if tt.Re == nil || !reflect.DeepEqual(regexp.MustCompile(tt.Re.String()), tt.Re) {
+			// The regular expression has another syntax or semantics than the default, like CompilePOSIX.
+			return nil, false
+		}
+
+		parsed, err := syntax.Parse(tt.Re.String(), syntax.Perl)
+		if err != nil || !anchored(parsed) || new(byteSet).addFirst(parsed) {
+			return nil, false
+		}
+
+		if re, err = dfa.ParseRule(tt.Re.String()); err != nil {
+			return nil, false
+		}
+	case *PatternTokenType:
+		for _, p := range tt.Patterns {
+			if p == "" || !utf8.ValidString(p) {
+				return nil, false
+			}
+		}
+		re = dfa.LiteralRule(tt.Patterns)
+	default:
+		return nil, false
+	}
+
+	if _, err := dfa.Build([]*syntax.Regexp{re}); err != nil {
+		return nil, false
+	}
+	return re, true
+}
+
+// token makes a Token of the rule that DFA matched.
+func (seg compiledSegment) token(rule int, s string, length int, p Position) *Token {
+	switch tt := seg.rules[rule].(type) {
+	case *RegexpTokenType:
+		if tt.Re.NumSubexp() > 0 {
+			return tt.FindToken(s, p)
+		}
+		return &Token{Type: tt, Literal: s[:length], Submatches: []string{}, Position: p}
+	default:
+		return &Token{Type: tt, Literal: s[:length], Position: p}
+	}
+}
+
+// find returns a token of this segment. If longest is true, returns the longest one.
+func (seg compiledSegment) find(s string, p Position, longest bool) *Token {
+	if seg.other != nil {
+		return seg.other.FindToken(s, p)
+	}
+
+	rule, length := seg.dfa.Match(s, longest)
+	if rule < 0 {
+		return nil
+	}
+	return seg.token(rule, s, length, p)
+}
+
+// byteSet is a set of bytes.
+type byteSet [256]bool
+
+// addRange adds the first bytes of UTF-8 encoded runes from lo to hi.
+func (bs *byteSet) addRange(lo, hi rune) {
+	for r := lo; r <= hi && r < utf8.RuneSelf; r++ {
+		bs[r] = true
+	}
+	if hi >= utf8.RuneSelf {
+		// Invalid UTF-8 bytes are treated as utf8.RuneError by regexp, so all non-ASCII bytes are candidates.
+		for b := utf8.RuneSelf; b < len(bs); b++ {
+			bs[b] = true
+		}
+	}
+}
+
+// anchored reports whether re matches only at the head of the text.
+func anchored(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginText:
+		return true
+	case syntax.OpCapture:
+		return anchored(re.Sub[0])
+	case syntax.OpConcat:
+		return len(re.Sub) > 0 && anchored(re.Sub[0])
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !anchored(sub) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// addFirst adds bytes that re can start with, and returns whether re can match the empty string.
+func (bs *byteSet) addFirst(re *syntax.Regexp) (nullable bool) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return true
+		}
+		r := re.Rune[0]
+		bs.addRange(r, r)
+		if re.Flags&syntax.FoldCase != 0 {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				bs.addRange(f, f)
+			}
+		}
+		return false
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			bs.addRange(re.Rune[i], re.Rune[i+1])
+		}
+		return false
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		bs.addRange(0, unicode.MaxRune)
+		return false
+	case syntax.OpCapture:
+		return bs.addFirst(re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !bs.addFirst(sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if bs.addFirst(sub) {
+				nullable = true
+			}
+		}
+		return nullable
+	case syntax.OpStar, syntax.OpQuest:
+		bs.addFirst(re.Sub[0])
+		return true
+	case syntax.OpPlus:
+		return bs.addFirst(re.Sub[0])
+	case syntax.OpRepeat:
+		return bs.addFirst(re.Sub[0]) || re.Min == 0
+	default:
+		// Empty-width assertions like ^ and \b consume nothing.
+		return true
+	}
+}
+
+// Get readable string of compiled TokenTypes.
+func (ctt *CompiledTokenType) String() string {
+	ss := make([]string, len(ctt.TokenTypes))
+	for i, tt := range ctt.TokenTypes {
+		ss[i] = typeString(tt)
+	}
+	return "COMPILED(" + strings.Join(ss, ", ") + ")"
+}
+
+/*
+GetID returns OTHER.
+
+The TokenID of CompiledTokenType has no meaning, because it is never used as Token.Type.
+*/
+func (ctt *CompiledTokenType) GetID() TokenID {
+	return OTHER
+}
+
+func (ctt *CompiledTokenType) needsAllInput() bool {
+	for _, tokenType := range ctt.TokenTypes {
+		if needsAllInput(tokenType) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindToken returns new Token if s starts with one of compiled TokenTypes. The first matched TokenType is used.
+func (ctt *CompiledTokenType) FindToken(s string, p Position) *Token {
+	for _, seg := range ctt.segments {
+		if t := seg.find(s, p, false); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// findLongest returns the longest token like LongestMatch. It is used by Lexer instead of FindToken.
+func (ctt *CompiledTokenType) findLongest(s string, p Position) *Token {
+	var found *Token
+	for _, seg := range ctt.segments {
+		if t := seg.find(s, p, true); t != nil && (found == nil || len(t.Literal) > len(found.Literal)) {
+			found = t
+		}
+	}
+	return found
+}
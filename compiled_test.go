@@ -0,0 +1,159 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewCompiledTokenType() {
+	lexer := simplexer.NewLexer(strings.NewReader("x = 1.5"))
+
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewCompiledTokenType(lexer.TokenTypes),
+	}
+
+	for {
+		token, _ := lexer.Scan()
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%s: %s\n", token.Type, token.Literal)
+	}
+
+	// Output:
+	// IDENT: x
+	// OTHER: =
+	// NUMBER: 1.5
+}
+
+type prefixTokenType struct {
+	ID     simplexer.TokenID
+	Prefix string
+}
+
+func (ptt *prefixTokenType) GetID() simplexer.TokenID {
+	return ptt.ID
+}
+
+func (ptt *prefixTokenType) FindToken(s string, p simplexer.Position) *simplexer.Token {
+	if strings.HasPrefix(s, ptt.Prefix) {
+		return &simplexer.Token{Type: ptt, Literal: ptt.Prefix, Position: p}
+	}
+	return nil
+}
+
+func scanAll(t *testing.T, lexer *simplexer.Lexer) []*simplexer.Token {
+	var tokens []*simplexer.Token
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			return tokens
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+func TestCompiledTokenType_differential(t *testing.T) {
+	types := []simplexer.TokenType{
+		simplexer.NewPatternTokenType(0, []string{"=", "==", "!="}),
+		simplexer.NewPatternTokenType(1, nil),
+		simplexer.NewRegexpTokenType(2, `(?i)sel(ect)?`),
+		&prefixTokenType{ID: 10, Prefix: "@@"},
+		simplexer.NewRegexpTokenType(3, `^([a-z]+)(:([0-9]+))?`),
+		simplexer.NewRegexpTokenType(4, `\$\{([^}]*)\}|\$([a-z]+)`),
+	}
+	types = append(types, simplexer.DefaultTokenTypes...)
+
+	inputs := []string{
+		"\t10; literal\nhoge = \"abc\"",
+		"this is \"one line\"",
+		"SELECT a == b != c; Sel @@ x:12 y:z ${foo} $bar\n\"multi\nline\" 1.5",
+		"@@@ a= =b ==c ${} $ 0.0.0",
+	}
+
+	for _, input := range inputs {
+		sequential := simplexer.NewLexer(strings.NewReader(input))
+		sequential.TokenTypes = types
+
+		compiled := simplexer.NewLexer(strings.NewReader(input))
+		compiled.TokenTypes = []simplexer.TokenType{simplexer.NewCompiledTokenType(types)}
+
+		excepts := scanAll(t, sequential)
+		tokens := scanAll(t, compiled)
+
+		if len(tokens) != len(excepts) {
+			t.Fatalf("excepted %d tokens but got %d tokens", len(excepts), len(tokens))
+		}
+
+		for i, except := range excepts {
+			if tokens[i].Type != except.Type {
+				t.Errorf("%d: excepted type %s but got %s", i, except.Type.GetID(), tokens[i].Type.GetID())
+			}
+			if tokens[i].Literal != except.Literal {
+				t.Errorf("%d: excepted literal %#v but got %#v", i, except.Literal, tokens[i].Literal)
+			}
+			if !reflect.DeepEqual(tokens[i].Submatches, except.Submatches) {
+				t.Errorf("%d: excepted submatches %#v but got %#v", i, except.Submatches, tokens[i].Submatches)
+			}
+			if tokens[i].Position != except.Position {
+				t.Errorf("%d: excepted position %s but got %s", i, except.Position, tokens[i].Position)
+			}
+		}
+	}
+}
+
+func TestCompiledTokenType_semantics(t *testing.T) {
+	posix := regexp.MustCompilePOSIX(`^(a|ab)`)
+	longest := regexp.MustCompile(`^(?:k|kkm)`)
+	longest.Longest()
+	types := []simplexer.TokenType{
+		&simplexer.RegexpTokenType{ID: 0, Re: posix},
+		&simplexer.RegexpTokenType{ID: 1, Re: regexp.MustCompile(`[0-9]+`)},
+		simplexer.NewRegexpTokenType(2, `x*y`),
+		simplexer.NewPatternTokenType(3, []string{"=", "=="}),
+		simplexer.NewRegexpTokenType(4, `(?i)é+|==+`),
+		simplexer.NewRegexpTokenType(6, `(c)(d)?\b|c+`),
+		simplexer.NewPatternTokenType(7, []string{"\xff\xfe", "\xff"}),
+		&simplexer.RegexpTokenType{ID: 8, Re: longest},
+		simplexer.NewRegexpTokenType(9, `(?U)e+|f*?g|(h)(h)`),
+		simplexer.NewRegexpTokenType(5, `.`),
+	}
+
+	inputs := []string{"ab", "1 a2", "x xxy", "y1", "===", "ÉéZ", "\xff", "\xff\xfe", "cd ccc cx", "kkm", "eee fffg hh"}
+	ctt := simplexer.NewCompiledTokenType(types)
+
+	for _, input := range inputs {
+		for _, strategy := range []simplexer.MatchStrategy{simplexer.FirstMatch, simplexer.LongestMatch} {
+			sequential := simplexer.NewLexerString(input)
+			sequential.TokenTypes = types
+			sequential.Strategy = strategy
+
+			compiled := simplexer.NewLexerString(input)
+			compiled.TokenTypes = []simplexer.TokenType{ctt}
+			compiled.Strategy = strategy
+
+			except, _ := sequential.Tokens()
+			got, _ := compiled.Tokens()
+
+			if len(got) != len(except) {
+				t.Errorf("%#v/%d: excepted %d tokens but got %d", input, strategy, len(except), len(got))
+				continue
+			}
+			for i := range except {
+				if got[i].Type != except[i].Type || got[i].Literal != except[i].Literal || !reflect.DeepEqual(got[i].Submatches, except[i].Submatches) {
+					t.Errorf("%#v/%d: %d: excepted %s but got %s", input, strategy, i, except[i], got[i])
+				}
+			}
+		}
+	}
+}
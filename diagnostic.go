@@ -0,0 +1,189 @@
+package simplexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[1;31m"
+	ansiBlue  = "\x1b[1;34m"
+)
+
+/*
+DiagnosticRenderer renders errors from Lexer for human or machine.
+
+Filename is the name of the source file.
+Position.Filename of the error is used if it is empty, and omitted if both of them are empty.
+
+Color enables ANSI colors for Render.
+
+TabWidth is the width of tab stops for expanding tabs in the source line.
+DefaultTabWidth is used if it is 0.
+
+Registry is a TokenRegistry for names of TokenID in messages. DefaultRegistry is used if it is nil.
+*/
+type DiagnosticRenderer struct {
+	Filename string
+	Color    bool
+	TabWidth int
+	Registry *TokenRegistry
+}
+
+func (r DiagnosticRenderer) paint(color, s string) string {
+	if !r.Color {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+func (r DiagnosticRenderer) location(p Position) string {
+	if r.Filename != "" {
+		p.Filename = r.Filename
+	}
+	return p.location()
+}
+
+func (r DiagnosticRenderer) message(err error) (string, Position, bool) {
+	registry := r.Registry
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+
+	switch e := err.(type) {
+	case UnknownTokenError:
+		return fmt.Sprintf("unknown token %#v", e.Literal), e.Position, true
+	case ReaderError:
+		return "failed to read: " + e.Err.Error(), e.Position, true
+	case UnknownStateError:
+		return fmt.Sprintf("unknown state %#v", e.State), e.Position, true
+	case StateStackError:
+		return "can not pop the last state", e.Position, true
+	case UnterminatedStringError:
+		return "string literal not terminated", e.Position, true
+	case UnterminatedCommentError:
+		return "comment not terminated", e.Position, true
+	case InvalidEscapeError:
+		return fmt.Sprintf("invalid escape sequence %#v", e.Sequence), e.Position, true
+	case IndentationError:
+		return e.Message, e.Position, true
+	case MalformedNumberError:
+		return "malformed number: " + e.Message, e.Position, true
+	case UnexpectedTokenError:
+		return e.message(registry), e.Token.Position, true
+	case TooManyErrorsError:
+		return fmt.Sprintf("too many errors (%d)", e.Count), e.Position, true
+	default:
+		return err.Error(), Position{}, false
+	}
+}
+
+// expandTabs replaces tabs in s with spaces, and returns display width of the head n bytes of s.
+func (r DiagnosticRenderer) expandTabs(s string, n int) (string, int) {
+	tabWidth := r.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = DefaultTabWidth
+	}
+
+	var buf strings.Builder
+	width := 0
+	headWidth := -1
+
+	for i, c := range s {
+		if i >= n && headWidth < 0 {
+			headWidth = width
+		}
+
+		if c == '\t' {
+			next := (width/tabWidth + 1) * tabWidth
+			buf.WriteString(strings.Repeat(" ", next-width))
+			width = next
+		} else {
+			buf.WriteRune(c)
+			width += runeWidth(c)
+		}
+	}
+
+	if headWidth < 0 {
+		headWidth = width
+	}
+
+	return buf.String(), headWidth
+}
+
+/*
+Render returns a diagnostic message for human.
+
+If err is UnknownTokenError, the message includes the source line and underline of the unknown token, like below.
+
+	error: unknown token "error"
+	 --> source.txt:1:5
+	  |
+	1 | 1 2 error 3 4
+	  |     ^^^^^
+*/
+func (r DiagnosticRenderer) Render(err error) string {
+	msg, pos, ok := r.message(err)
+
+	var buf strings.Builder
+	buf.WriteString(r.paint(ansiRed, "error") + r.paint(ansiBold, ": "+msg) + "\n")
+	if !ok {
+		return buf.String()
+	}
+
+	lineNum := strconv.Itoa(pos.Line + 1)
+	gutter := strings.Repeat(" ", len(lineNum))
+
+	buf.WriteString(gutter + r.paint(ansiBlue, "--> ") + r.location(pos) + "\n")
+
+	ute, ok := err.(UnknownTokenError)
+	if !ok {
+		return buf.String()
+	}
+
+	literal := ute.Literal
+	if idx := strings.Index(literal, "\n"); idx >= 0 {
+		literal = literal[:idx]
+	}
+
+	column := pos.Column
+	if column > len(ute.Line) {
+		column = len(ute.Line)
+	}
+
+	line, head := r.expandTabs(ute.Line, column)
+	_, tail := r.expandTabs(ute.Line, column+len(literal))
+
+	underline := tail - head
+	if underline < 1 {
+		underline = 1
+	}
+
+	buf.WriteString(gutter + r.paint(ansiBlue, " |") + "\n")
+	buf.WriteString(r.paint(ansiBlue, lineNum+" |") + " " + line + "\n")
+	buf.WriteString(gutter + r.paint(ansiBlue, " |") + " " + strings.Repeat(" ", head) + r.paint(ansiRed, strings.Repeat("^", underline)) + "\n")
+
+	return buf.String()
+}
+
+/*
+RenderPlain returns a diagnostic message in one line for machine.
+
+The format is "filename:line:column: error: message", like below.
+Filename is omitted if DiagnosticRenderer.Filename is empty.
+
+	source.txt:1:5: error: unknown token "error"
+*/
+func (r DiagnosticRenderer) RenderPlain(err error) string {
+	msg, pos, ok := r.message(err)
+	if !ok {
+		if r.Filename != "" {
+			return r.Filename + ": error: " + msg
+		}
+		return "error: " + msg
+	}
+	return r.location(pos) + ": error: " + msg
+}
@@ -0,0 +1,110 @@
+package simplexer_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleDiagnosticRenderer() {
+	lexer := simplexer.NewLexer(strings.NewReader("x = 1\ny = 2 ?? 3\n"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.IDENT, `[a-z]+`),
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+		simplexer.NewPatternTokenType(simplexer.OTHER, []string{"="}),
+	}
+
+	renderer := simplexer.DiagnosticRenderer{Filename: "example.txt"}
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			fmt.Print(renderer.Render(err))
+			fmt.Println(renderer.RenderPlain(err))
+			break
+		}
+		if token == nil {
+			break
+		}
+	}
+
+	// Output:
+	// error: unknown token "??"
+	//  --> example.txt:2:7
+	//   |
+	// 2 | y = 2 ?? 3
+	//   |       ^^
+	// example.txt:2:7: error: unknown token "??"
+}
+
+func TestDiagnosticRenderer_Render(t *testing.T) {
+	tests := []struct {
+		Renderer simplexer.DiagnosticRenderer
+		Err      error
+		Except   string
+	}{
+		{
+			simplexer.DiagnosticRenderer{},
+			simplexer.UnknownTokenError{Literal: "b", Position: simplexer.Position{Line: 9, Column: 3}, Line: "\ta\tb"},
+			"error: unknown token \"b\"\n" +
+				"  --> 10:4\n" +
+				"   |\n" +
+				"10 |         a       b\n" +
+				"   |                 ^\n",
+		},
+		{
+			simplexer.DiagnosticRenderer{TabWidth: 2},
+			simplexer.UnknownTokenError{Literal: "名前\nx", Position: simplexer.Position{Line: 0, Column: 2}, Line: "\t\t名前"},
+			"error: unknown token \"名前\\nx\"\n" +
+				" --> 1:3\n" +
+				"  |\n" +
+				"1 |     名前\n" +
+				"  |     ^^^^\n",
+		},
+		{
+			simplexer.DiagnosticRenderer{Filename: "test.txt", Color: true},
+			simplexer.UnknownTokenError{Literal: "", Position: simplexer.Position{Line: 0, Column: 3}, Line: "abc"},
+			"\x1b[1;31merror\x1b[0m\x1b[1m: unknown token \"\"\x1b[0m\n" +
+				" \x1b[1;34m--> \x1b[0mtest.txt:1:4\n" +
+				" \x1b[1;34m |\x1b[0m\n" +
+				"\x1b[1;34m1 |\x1b[0m abc\n" +
+				" \x1b[1;34m |\x1b[0m    \x1b[1;31m^\x1b[0m\n",
+		},
+		{
+			simplexer.DiagnosticRenderer{Filename: "test.txt"},
+			simplexer.TooManyErrorsError{Count: 5, Position: simplexer.Position{Line: 1, Column: 1}},
+			"error: too many errors (5)\n" +
+				" --> test.txt:2:2\n",
+		},
+		{
+			simplexer.DiagnosticRenderer{},
+			errors.New("something wrong"),
+			"error: something wrong\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if s := tt.Renderer.Render(tt.Err); s != tt.Except {
+			t.Errorf("excepted:\n%s\nbut got:\n%s", tt.Except, s)
+		}
+	}
+}
+
+func TestDiagnosticRenderer_RenderPlain(t *testing.T) {
+	err := simplexer.UnknownTokenError{Literal: "?", Position: simplexer.Position{Line: 2, Column: 4}}
+
+	if s := (simplexer.DiagnosticRenderer{}).RenderPlain(err); s != "3:5: error: unknown token \"?\"" {
+		t.Errorf("unexcepted output: %#v", s)
+	}
+
+	if s := (simplexer.DiagnosticRenderer{Filename: "a.txt"}).RenderPlain(err); s != "a.txt:3:5: error: unknown token \"?\"" {
+		t.Errorf("unexcepted output: %#v", s)
+	}
+
+	if s := (simplexer.DiagnosticRenderer{Filename: "a.txt"}).RenderPlain(errors.New("test")); s != "a.txt: error: test" {
+		t.Errorf("unexcepted output: %#v", s)
+	}
+}
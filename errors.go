@@ -2,13 +2,192 @@ package simplexer
 
 import "fmt"
 
-// The error that returns when found an unknown token.
+/*
+The error that returns when found an unknown token.
+
+Line is the whole line of source that includes the unknown token.
+It is used by DiagnosticRenderer for showing where is wrong.
+*/
 type UnknownTokenError struct {
 	Literal  string
 	Position Position
+	Line     string
 }
 
 // Get error message as string.
 func (se UnknownTokenError) Error() string {
-	return fmt.Sprintf("%d:%d:UnknownTokenError: %#v", se.Position.Line+1, se.Position.Column+1, se.Literal)
+	return fmt.Sprintf("%s:UnknownTokenError: %#v", se.Position.location(), se.Literal)
+}
+
+// The error that returns when failed to read from the io.Reader of Lexer.
+type ReaderError struct {
+	Err      error
+	Position Position
+}
+
+// Get error message as string.
+func (re ReaderError) Error() string {
+	return fmt.Sprintf("%s:ReaderError: %s", re.Position.location(), re.Err)
+}
+
+// Unwrap returns the error that returned from io.Reader.
+func (re ReaderError) Unwrap() error {
+	return re.Err
+}
+
+// The error that returns when tried to change into a state that not defined in Lexer.States.
+type UnknownStateError struct {
+	State    string
+	Position Position
+}
+
+// Get error message as string.
+func (se UnknownStateError) Error() string {
+	return fmt.Sprintf("%s:UnknownStateError: %#v", se.Position.location(), se.State)
+}
+
+// The error that returns when tried to pop the last state from the state stack.
+type StateStackError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (se StateStackError) Error() string {
+	return fmt.Sprintf("%s:StateStackError: can not pop the last state", se.Position.location())
+}
+
+// The error that returns when the number of errors exceeded Lexer.MaxErrors.
+type TooManyErrorsError struct {
+	Count    int
+	Position Position
+}
+
+// Get error message as string.
+func (te TooManyErrorsError) Error() string {
+	return fmt.Sprintf("%s:TooManyErrorsError: found %d errors", te.Position.location(), te.Count)
+}
+
+// The error that returns when tried to reset Lexer to a released Mark.
+type InvalidMarkError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (me InvalidMarkError) Error() string {
+	return fmt.Sprintf("%s:InvalidMarkError: the mark was already released", me.Position.location())
+}
+
+// The error that returns when a string literal has no end quote. Position is the start of the string.
+type UnterminatedStringError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (se UnterminatedStringError) Error() string {
+	return fmt.Sprintf("%s:UnterminatedStringError: string literal not terminated", se.Position.location())
+}
+
+// The error that returns when a string literal has an invalid escape sequence.
+type InvalidEscapeError struct {
+	Sequence string
+	Position Position
+}
+
+// Get error message as string.
+func (ee InvalidEscapeError) Error() string {
+	return fmt.Sprintf("%s:InvalidEscapeError: %#v", ee.Position.location(), ee.Sequence)
+}
+
+// The error that returns when a numeric literal is malformed. Position is the point of the problem.
+type MalformedNumberError struct {
+	Message  string
+	Position Position
+}
+
+// Get error message as string.
+func (ne MalformedNumberError) Error() string {
+	return fmt.Sprintf("%s:MalformedNumberError: %s", ne.Position.location(), ne.Message)
+}
+
+// The error that returns when a block comment is not closed. Position is the start of the comment.
+type UnterminatedCommentError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (ce UnterminatedCommentError) Error() string {
+	return fmt.Sprintf("%s:UnterminatedCommentError: comment not terminated", ce.Position.location())
+}
+
+// The error that returns when indentation is inconsistent in Lexer.Indentation mode.
+type IndentationError struct {
+	Message  string
+	Position Position
+}
+
+// Get error message as string.
+func (ie IndentationError) Error() string {
+	return fmt.Sprintf("%s:IndentationError: %s", ie.Position.location(), ie.Message)
+}
+
+/*
+The error that returns when Spec is broken or invalid.
+
+Path is the path to the invalid value in Spec, like "tokens[1].regexp".
+Position is the position in the source of Spec, and HasPosition reports whether Position is set.
+They are set only by ParseSpec, because other formats have no position.
+*/
+type SpecError struct {
+	Path        string
+	Message     string
+	Position    Position
+	HasPosition bool
+}
+
+// Get error message as string.
+func (se SpecError) Error() string {
+	switch {
+	case se.HasPosition && se.Path != "":
+		return fmt.Sprintf("%s:SpecError: %s: %s", se.Position.location(), se.Path, se.Message)
+	case se.HasPosition:
+		return fmt.Sprintf("%s:SpecError: %s", se.Position.location(), se.Message)
+	case se.Path != "":
+		return fmt.Sprintf("%s:SpecError: %s", se.Path, se.Message)
+	default:
+		return "SpecError: " + se.Message
+	}
+}
+
+/*
+The error for parsers that found an unexpected token.
+
+Expected is a list of TokenIDs that expected instead of Token. It can be empty.
+The message uses names in DefaultRegistry. DiagnosticRenderer.Registry can be used for another TokenRegistry.
+*/
+type UnexpectedTokenError struct {
+	Token    *Token
+	Expected []TokenID
+}
+
+// Get error message as string.
+func (ue UnexpectedTokenError) Error() string {
+	return fmt.Sprintf("%s:UnexpectedTokenError: %s", ue.Token.Position.location(), ue.message(DefaultRegistry))
+}
+
+func (ue UnexpectedTokenError) message(r *TokenRegistry) string {
+	msg := fmt.Sprintf("unexpected %s %#v", r.String(ue.Token.Type.GetID()), ue.Token.Literal)
+
+	for i, id := range ue.Expected {
+		switch {
+		case i == 0:
+			msg += ", expected "
+		case i == len(ue.Expected)-1:
+			msg += " or "
+		default:
+			msg += ", "
+		}
+		msg += r.String(id)
+	}
+
+	return msg
 }
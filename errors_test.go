@@ -1,6 +1,8 @@
 package simplexer_test
 
 import (
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/macrat/simplexer"
@@ -14,3 +16,79 @@ func TestUnknownTokenError(t *testing.T) {
 		t.Errorf("excepted %#v but got %s", except, err.Error())
 	}
 }
+
+func TestReaderError(t *testing.T) {
+	err := simplexer.ReaderError{Err: io.ErrUnexpectedEOF, Position: simplexer.Position{Line: 2, Column: 3}}
+	except := "3:4:ReaderError: unexpected EOF"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("excepted wrapped io.ErrUnexpectedEOF but got %#v", err.Unwrap())
+	}
+}
+
+func TestTooManyErrorsError(t *testing.T) {
+	err := simplexer.TooManyErrorsError{Count: 10, Position: simplexer.Position{Line: 1, Column: 0}}
+	except := "2:1:TooManyErrorsError: found 10 errors"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestInvalidMarkError(t *testing.T) {
+	err := simplexer.InvalidMarkError{Position: simplexer.Position{Filename: "a.txt", Line: 0, Column: 4}}
+	except := "a.txt:1:5:InvalidMarkError: the mark was already released"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestUnterminatedStringError(t *testing.T) {
+	err := simplexer.UnterminatedStringError{Position: simplexer.Position{Line: 2, Column: 0}}
+	except := "3:1:UnterminatedStringError: string literal not terminated"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestInvalidEscapeError(t *testing.T) {
+	err := simplexer.InvalidEscapeError{Sequence: "\\q", Position: simplexer.Position{Line: 0, Column: 3}}
+	except := "1:4:InvalidEscapeError: \"\\\\q\""
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestMalformedNumberError(t *testing.T) {
+	err := simplexer.MalformedNumberError{Message: "exponent has no digits", Position: simplexer.Position{Line: 0, Column: 2}}
+	except := "1:3:MalformedNumberError: exponent has no digits"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestUnterminatedCommentError(t *testing.T) {
+	err := simplexer.UnterminatedCommentError{Position: simplexer.Position{Line: 1, Column: 2}}
+	except := "2:3:UnterminatedCommentError: comment not terminated"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
+
+func TestIndentationError(t *testing.T) {
+	err := simplexer.IndentationError{Message: "tab in indentation", Position: simplexer.Position{Line: 1, Column: 1}}
+	except := "2:2:IndentationError: tab in indentation"
+
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %s", except, err.Error())
+	}
+}
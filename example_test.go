@@ -83,9 +83,12 @@ func Example_positionInformation() {
 func Example_addOriginalTokenType() {
 	const (
 		SUBSITUATION simplexer.TokenID = iota
-		NEWLINE
+		LINEBREAK
 	)
 
+	simplexer.DefaultRegistry.Register(SUBSITUATION, "SUBSITUATION", simplexer.OperatorCategory)
+	simplexer.DefaultRegistry.Register(LINEBREAK, "LINEBREAK", simplexer.NoCategory)
+
 	input := "hello_world = \"hello world\"\nnumber = 1"
 	lexer := simplexer.NewLexer(strings.NewReader(input))
 
@@ -94,7 +97,7 @@ func Example_addOriginalTokenType() {
 
 	lexer.TokenTypes = append([]simplexer.TokenType{
 		simplexer.NewPatternTokenType(SUBSITUATION, []string{"="}),
-		simplexer.NewRegexpTokenType(NEWLINE, `^[\n\r]+`),
+		simplexer.NewRegexpTokenType(LINEBREAK, `^[\n\r]+`),
 	}, lexer.TokenTypes...)
 
 	fmt.Println(input)
@@ -118,11 +121,11 @@ func Example_addOriginalTokenType() {
 	// number = 1
 	// ==========
 	// IDENT: "hello_world"
-	// UNKNOWN(0): "="
+	// SUBSITUATION: "="
 	// STRING: "\"hello world\""
-	// UNKNOWN(1): "\n"
+	// LINEBREAK: "\n"
 	// IDENT: "number"
-	// UNKNOWN(0): "="
+	// SUBSITUATION: "="
 	// NUMBER: "1"
 	// ==========
 }
@@ -0,0 +1,162 @@
+package simplexer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+/*
+Pos is a compact encoding of a position in FileSet.
+
+Pos can be converted to Position by FileSet.Position.
+The zero value is NoPos, that means no position.
+*/
+type Pos uint64
+
+// NoPos is the zero value of Pos.
+const NoPos Pos = 0
+
+const posOffsetBits = 32
+
+// IsValid reports whether p is not NoPos.
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+/*
+File is a source file in FileSet.
+
+File records heads of lines while Lexer reading the file,
+so a byte offset in the file can be converted to Position.
+*/
+type File struct {
+	index int
+	name  string
+
+	mu    sync.Mutex
+	lines []int
+}
+
+// Name returns the name of the file.
+func (f *File) Name() string {
+	return f.name
+}
+
+/*
+Pos returns Pos of the byte offset in the file.
+
+Pos can encode offsets up to 4GiB. It panics if offset is negative or too large.
+*/
+func (f *File) Pos(offset int) Pos {
+	if offset < 0 || uint64(offset) >= 1<<posOffsetBits {
+		panic(fmt.Sprintf("simplexer: offset %d of %s is out of range of Pos", offset, f.name))
+	}
+	return Pos(uint64(f.index)<<posOffsetBits | uint64(uint32(offset)))
+}
+
+// Offset returns the byte offset of p in the file.
+func (f *File) Offset(p Pos) int {
+	return int(uint32(p))
+}
+
+// LineCount returns the number of lines that read by Lexer.
+func (f *File) LineCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.lines)
+}
+
+func (f *File) addLine(offset int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.lines) == 0 || f.lines[len(f.lines)-1] < offset {
+		f.lines = append(f.lines, offset)
+	}
+}
+
+/*
+Position returns Position of the byte offset in the file.
+
+The column of result is in bytes. RuneColumn, UTF16Column and DisplayColumn are always 0.
+*/
+func (f *File) Position(offset int) Position {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	line := sort.Search(len(f.lines), func(i int) bool {
+		return f.lines[i] > offset
+	})
+
+	head := 0
+	if line > 0 {
+		head = f.lines[line-1]
+	}
+
+	return Position{
+		Filename: f.name,
+		Line:     line,
+		Column:   offset - head,
+	}
+}
+
+/*
+FileSet is a set of source files, like go/token.FileSet.
+
+Each Lexer can be associated with a File in FileSet by Lexer.File.
+Positions of tokens from the Lexer will have the name of the file,
+and the positions can be encoded into Pos compactly.
+*/
+type FileSet struct {
+	mu    sync.RWMutex
+	files []*File
+}
+
+// Make a new FileSet.
+func NewFileSet() *FileSet {
+	return new(FileSet)
+}
+
+// AddFile adds a new file into the set.
+func (fs *FileSet) AddFile(name string) *File {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f := &File{
+		index: len(fs.files) + 1,
+		name:  name,
+	}
+	fs.files = append(fs.files, f)
+	return f
+}
+
+// File returns the File that includes p, or nil if p is not in the set.
+func (fs *FileSet) File(p Pos) *File {
+	idx := int(p >> posOffsetBits)
+
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	if idx <= 0 || idx > len(fs.files) {
+		return nil
+	}
+	return fs.files[idx-1]
+}
+
+// Position converts p to Position. Returns the zero Position if p is not in the set.
+func (fs *FileSet) Position(p Pos) Position {
+	if f := fs.File(p); f != nil {
+		return f.Position(f.Offset(p))
+	}
+	return Position{}
+}
+
+// Files returns all files in the set.
+func (fs *FileSet) Files() []*File {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	return append([]*File(nil), fs.files...)
+}
@@ -0,0 +1,107 @@
+package simplexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func TestFileSet(t *testing.T) {
+	fset := simplexer.NewFileSet()
+
+	sources := map[string]string{
+		"a.txt": "hello world\nfoo\n\n  bar",
+		"b.txt": "x = 1\ny = \"multi\nline\" z",
+	}
+
+	var tokens []*simplexer.Token
+	var poses []simplexer.Pos
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		lexer := simplexer.NewLexer(strings.NewReader(sources[name]))
+		lexer.File = fset.AddFile(name)
+
+		for {
+			token, err := lexer.Scan()
+			if err != nil {
+				t.Fatalf("failed scan: %s", err.Error())
+			}
+			if token == nil {
+				break
+			}
+
+			if token.Position.Filename != name {
+				t.Errorf("excepted filename %#v but got %#v", name, token.Position.Filename)
+			}
+
+			tokens = append(tokens, token)
+			poses = append(poses, lexer.File.Pos(token.Offset))
+		}
+	}
+
+	for i, token := range tokens {
+		if pos := fset.Position(poses[i]); pos != token.Position {
+			t.Errorf("%s: excepted position %s but got %s", token, token.Position, pos)
+		}
+
+		if f := fset.File(poses[i]); f == nil || f.Name() != token.Position.Filename {
+			t.Errorf("%s: excepted file %#v but got %#v", token, token.Position.Filename, f)
+		}
+	}
+
+	if n := fset.Files()[0].LineCount(); n != 3 {
+		t.Errorf("excepted 3 line heads but got %d", n)
+	}
+
+	if pos := fset.Position(simplexer.NoPos); pos != (simplexer.Position{}) {
+		t.Errorf("excepted zero position but got %s", pos)
+	}
+
+	if f := fset.File(simplexer.NoPos); f != nil {
+		t.Errorf("excepted nil but got %#v", f)
+	}
+}
+
+func TestFileSet_error(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("abc\n  !"))
+	lexer.File = simplexer.NewFileSet().AddFile("test.txt")
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.IDENT, `[a-z]+`),
+	}
+
+	if _, err := lexer.Scan(); err != nil {
+		t.Fatalf("failed scan: %s", err.Error())
+	}
+
+	_, err := lexer.Scan()
+	if err == nil {
+		t.Fatalf("excepted error but got nil")
+	}
+
+	except := "test.txt:2:3:UnknownTokenError: \"!\""
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %#v", except, err.Error())
+	}
+}
+
+func TestFile_Pos_overflow(t *testing.T) {
+	fset := simplexer.NewFileSet()
+	f := fset.AddFile("large.txt")
+
+	var last uint64 = 1<<32 - 1
+	if off := f.Offset(f.Pos(int(last))); off != int(last) {
+		t.Errorf("excepted offset %d but got %d", last, off)
+	}
+
+	for _, offset := range []int{-1, int(last + 1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%d: excepted panic but not panicked", offset)
+				}
+			}()
+			f.Pos(offset)
+		}()
+	}
+}
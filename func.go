@@ -0,0 +1,281 @@
+package simplexer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Matcher is a function that matches the head of s.
+
+Returns the length of matched string in bytes, and whether matched.
+*/
+type Matcher func(s string) (length int, ok bool)
+
+/*
+FuncTokenType is a TokenType that uses a Matcher.
+
+ID is TokenID for this token type.
+
+Matcher is a function to find a token.
+The empty match is treated as not matched, because a token has to have at least one character.
+
+Matcher can't tell Lexer that it needs more input, so Lexer reads all the rest of input before using FuncTokenType.
+Please be careful with memory if the input is a large stream,
+and don't use FuncTokenType for interactive readers like os.Stdin, because Lexer blocks until the end of input.
+
+	digits := simplexer.Repeat(simplexer.Rune(unicode.IsDigit), 1, -1)
+	version := simplexer.NewFuncTokenType(VERSION, simplexer.Sequence(digits, simplexer.Literal("."), digits))
+*/
+type FuncTokenType struct {
+	ID      TokenID
+	Matcher Matcher
+}
+
+/*
+Make new FuncTokenType.
+
+id is a TokenID of new FuncTokenType.
+
+matcher is a Matcher. It can be made by CursorFunc, or by combinators like Sequence and Choice.
+*/
+func NewFuncTokenType(id TokenID, matcher Matcher) *FuncTokenType {
+	return &FuncTokenType{
+		ID:      id,
+		Matcher: matcher,
+	}
+}
+
+// Get readable string of TokenID.
+func (ftt *FuncTokenType) String() string {
+	return ftt.ID.String()
+}
+
+// GetID returns id of this token type.
+func (ftt *FuncTokenType) GetID() TokenID {
+	return ftt.ID
+}
+
+func (ftt *FuncTokenType) needsAllInput() bool {
+	return true
+}
+
+// FindToken returns new Token if Matcher matched to the head of s.
+func (ftt *FuncTokenType) FindToken(s string, p Position) *Token {
+	length, ok := ftt.Matcher(s)
+	if !ok || length <= 0 {
+		return nil
+	}
+	if length > len(s) {
+		length = len(s)
+	}
+
+	return &Token{
+		Type:     ftt,
+		Literal:  s[:length],
+		Position: p,
+	}
+}
+
+/*
+RuneCursor is a cursor for reading string rune by rune in CursorFunc.
+*/
+type RuneCursor struct {
+	s      string
+	offset int
+}
+
+// Offset returns the byte offset of the cursor.
+func (c *RuneCursor) Offset() int {
+	return c.offset
+}
+
+// Reset moves the cursor to the byte offset, for backtracking.
+func (c *RuneCursor) Reset(offset int) {
+	c.offset = offset
+}
+
+// EOF reports whether the cursor reached to the end of string.
+func (c *RuneCursor) EOF() bool {
+	return c.offset >= len(c.s)
+}
+
+// Rest returns the string after the cursor.
+func (c *RuneCursor) Rest() string {
+	return c.s[c.offset:]
+}
+
+// Consumed returns the string before the cursor.
+func (c *RuneCursor) Consumed() string {
+	return c.s[:c.offset]
+}
+
+// Peek returns the rune at the cursor without moving the cursor. Returns utf8.RuneError if reached to the end.
+func (c *RuneCursor) Peek() rune {
+	r, _ := utf8.DecodeRuneInString(c.s[c.offset:])
+	return r
+}
+
+// Next returns the rune at the cursor and moves the cursor. Returns utf8.RuneError if reached to the end.
+func (c *RuneCursor) Next() rune {
+	r, size := utf8.DecodeRuneInString(c.s[c.offset:])
+	c.offset += size
+	return r
+}
+
+// Accept moves the cursor if the string after the cursor starts with prefix.
+func (c *RuneCursor) Accept(prefix string) bool {
+	if strings.HasPrefix(c.s[c.offset:], prefix) {
+		c.offset += len(prefix)
+		return true
+	}
+	return false
+}
+
+// AcceptFunc moves the cursor while f returns true, and returns the number of accepted runes.
+func (c *RuneCursor) AcceptFunc(f func(rune) bool) int {
+	n := 0
+	for !c.EOF() {
+		r, size := utf8.DecodeRuneInString(c.s[c.offset:])
+		if !f(r) {
+			break
+		}
+		c.offset += size
+		n++
+	}
+	return n
+}
+
+// Match moves the cursor if m matched at the cursor.
+func (c *RuneCursor) Match(m Matcher) bool {
+	length, ok := match(m, c.s[c.offset:])
+	if ok {
+		c.offset += length
+	}
+	return ok
+}
+
+/*
+CursorFunc makes a Matcher from a function that reads string by RuneCursor.
+
+f returns whether matched. The matched string is until the cursor when f returned.
+*/
+func CursorFunc(f func(c *RuneCursor) bool) Matcher {
+	return func(s string) (int, bool) {
+		c := &RuneCursor{s: s}
+		if !f(c) {
+			return 0, false
+		}
+		return c.offset, true
+	}
+}
+
+// match calls m, and clamps the length into s for avoiding out of range by a wrong Matcher.
+func match(m Matcher, s string) (int, bool) {
+	length, ok := m(s)
+	if length < 0 {
+		length = 0
+	} else if length > len(s) {
+		length = len(s)
+	}
+	return length, ok
+}
+
+// Literal makes a Matcher that matches to s.
+func Literal(s string) Matcher {
+	return func(x string) (int, bool) {
+		if strings.HasPrefix(x, s) {
+			return len(s), true
+		}
+		return 0, false
+	}
+}
+
+// Rune makes a Matcher that matches to a rune that f returns true.
+func Rune(f func(rune) bool) Matcher {
+	return func(s string) (int, bool) {
+		r, size := utf8.DecodeRuneInString(s)
+		if size == 0 || !f(r) {
+			return 0, false
+		}
+		return size, true
+	}
+}
+
+// Sequence makes a Matcher that matches to all of ms in order.
+func Sequence(ms ...Matcher) Matcher {
+	return func(s string) (int, bool) {
+		offset := 0
+		for _, m := range ms {
+			length, ok := match(m, s[offset:])
+			if !ok {
+				return 0, false
+			}
+			offset += length
+		}
+		return offset, true
+	}
+}
+
+// Choice makes a Matcher that matches to the first matched one of ms.
+func Choice(ms ...Matcher) Matcher {
+	return func(s string) (int, bool) {
+		for _, m := range ms {
+			if length, ok := match(m, s); ok {
+				return length, true
+			}
+		}
+		return 0, false
+	}
+}
+
+/*
+Repeat makes a Matcher that matches to m repeatedly, at least min times and at most max times.
+
+max is unlimited if it is negative.
+Repeat stops if m matched to an empty string, to avoid infinite loop.
+*/
+func Repeat(m Matcher, min, max int) Matcher {
+	return func(s string) (int, bool) {
+		offset, n := 0, 0
+		for max < 0 || n < max {
+			length, ok := match(m, s[offset:])
+			if !ok {
+				break
+			}
+			offset += length
+			n++
+
+			if length == 0 {
+				break
+			}
+		}
+
+		if n < min {
+			return 0, false
+		}
+		return offset, true
+	}
+}
+
+// Optional makes a Matcher that matches to m, or matches to an empty string if m didn't match.
+func Optional(m Matcher) Matcher {
+	return Repeat(m, 0, 1)
+}
+
+/*
+Not makes a Matcher that matches to an empty string if m didn't match.
+
+Not doesn't consume any character. It is useful with Sequence, like below.
+
+	// Any characters until "-->".
+	simplexer.Repeat(simplexer.Sequence(simplexer.Not(simplexer.Literal("-->")), simplexer.Rune(anyRune)), 0, -1)
+*/
+func Not(m Matcher) Matcher {
+	return func(s string) (int, bool) {
+		if _, ok := m(s); ok {
+			return 0, false
+		}
+		return 0, true
+	}
+}
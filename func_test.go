@@ -0,0 +1,205 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleCursorFunc() {
+	const HEREDOC simplexer.TokenID = 1
+
+	// Heredoc like "<<END\n...\nEND".
+	heredoc := simplexer.NewFuncTokenType(HEREDOC, simplexer.CursorFunc(func(c *simplexer.RuneCursor) bool {
+		if !c.Accept("<<") {
+			return false
+		}
+
+		start := c.Offset()
+		if c.AcceptFunc(unicode.IsUpper) == 0 || !c.Accept("\n") {
+			return false
+		}
+		terminator := "\n" + c.Consumed()[start:c.Offset()-1]
+
+		idx := strings.Index(c.Rest(), terminator)
+		if idx < 0 {
+			return false
+		}
+		c.Reset(c.Offset() + idx + len(terminator))
+		return true
+	}))
+
+	lexer := simplexer.NewLexer(strings.NewReader("cat <<END\nhello\nworld\nEND\necho"))
+	lexer.TokenTypes = append([]simplexer.TokenType{heredoc}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%#v\n", token.Literal)
+	}
+
+	// Output:
+	// "cat"
+	// "<<END\nhello\nworld\nEND"
+	// "echo"
+}
+
+func TestFuncTokenType(t *testing.T) {
+	ftt := simplexer.NewFuncTokenType(simplexer.IDENT, func(s string) (int, bool) {
+		return strings.IndexByte(s, ';'), strings.Contains(s, ";")
+	})
+
+	tok := ftt.FindToken("abc;def", simplexer.Position{Line: 1})
+	if tok == nil || tok.Literal != "abc" || tok.Type != ftt || tok.Position.Line != 1 {
+		t.Errorf("excepted IDENT(\"abc\") but got %v", tok)
+	}
+
+	for _, input := range []string{";", "abc"} {
+		if tok := ftt.FindToken(input, simplexer.Position{}); tok != nil {
+			t.Errorf("%#v: excepted nil but got %s", input, tok)
+		}
+	}
+}
+
+func TestMatcherCombinators(t *testing.T) {
+	digit := simplexer.Rune(unicode.IsDigit)
+	digits := simplexer.Repeat(digit, 1, -1)
+	anyRune := simplexer.Rune(func(rune) bool { return true })
+
+	tests := []struct {
+		Name    string
+		Matcher simplexer.Matcher
+		Input   string
+		Length  int
+		OK      bool
+	}{
+		{"literal", simplexer.Literal("ab"), "abc", 2, true},
+		{"literal", simplexer.Literal("ab"), "ba", 0, false},
+		{"rune", simplexer.Rune(unicode.IsLetter), "あい", 3, true},
+		{"rune", simplexer.Rune(unicode.IsLetter), "", 0, false},
+		{"sequence", simplexer.Sequence(digits, simplexer.Literal("."), digits), "12.34.5", 5, true},
+		{"sequence", simplexer.Sequence(digits, simplexer.Literal("."), digits), "12.", 0, false},
+		{"sequence", simplexer.Sequence(), "abc", 0, true},
+		{"choice", simplexer.Choice(simplexer.Literal("a"), simplexer.Literal("ab")), "abc", 1, true},
+		{"choice", simplexer.Choice(simplexer.Literal("x"), digits), "12a", 2, true},
+		{"choice", simplexer.Choice(simplexer.Literal("x"), digits), "a", 0, false},
+		{"repeat", simplexer.Repeat(digit, 2, 3), "12345", 3, true},
+		{"repeat", simplexer.Repeat(digit, 2, 3), "1a", 0, false},
+		{"repeat", simplexer.Repeat(digit, 0, -1), "abc", 0, true},
+		{"repeat", simplexer.Repeat(simplexer.Optional(digit), 0, -1), "a", 0, true},
+		{"optional", simplexer.Optional(simplexer.Literal("-")), "-1", 1, true},
+		{"optional", simplexer.Optional(simplexer.Literal("-")), "1", 0, true},
+		{"not", simplexer.Not(digit), "a", 0, true},
+		{"not", simplexer.Not(digit), "1", 0, false},
+		{"until", simplexer.Sequence(simplexer.Literal("<!--"), simplexer.Repeat(simplexer.Sequence(simplexer.Not(simplexer.Literal("-->")), anyRune), 0, -1), simplexer.Literal("-->")), "<!-- a -- b -->c", 15, true},
+	}
+
+	for _, tc := range tests {
+		length, ok := tc.Matcher(tc.Input)
+		if length != tc.Length || ok != tc.OK {
+			t.Errorf("%s %#v: excepted (%d, %v) but got (%d, %v)", tc.Name, tc.Input, tc.Length, tc.OK, length, ok)
+		}
+	}
+}
+
+func TestMatcherCombinators_wrongLength(t *testing.T) {
+	wrong := func(s string) (int, bool) {
+		return len(s) + 10, true
+	}
+
+	tests := []struct {
+		Name    string
+		Matcher simplexer.Matcher
+		Length  int
+		OK      bool
+	}{
+		{"sequence", simplexer.Sequence(wrong, simplexer.Literal("x")), 0, false},
+		{"sequence", simplexer.Sequence(wrong, wrong), 2, true},
+		{"choice", simplexer.Choice(wrong), 2, true},
+		{"repeat", simplexer.Repeat(wrong, 0, 3), 2, true},
+	}
+
+	for _, tc := range tests {
+		length, ok := tc.Matcher("ab")
+		if length != tc.Length || ok != tc.OK {
+			t.Errorf("%s: excepted (%d, %v) but got (%d, %v)", tc.Name, tc.Length, tc.OK, length, ok)
+		}
+	}
+}
+
+func TestFuncTokenType_reader(t *testing.T) {
+	heredoc := simplexer.NewFuncTokenType(simplexer.STRING, simplexer.CursorFunc(func(c *simplexer.RuneCursor) bool {
+		if !c.Accept("<<END\n") {
+			return false
+		}
+
+		idx := strings.Index(c.Rest(), "\nEND")
+		if idx < 0 {
+			return false
+		}
+		c.Reset(c.Offset() + idx + len("\nEND"))
+		return true
+	}))
+
+	body := strings.Repeat("x", 5000)
+	input := "cat <<END\n" + body + "\nEND\necho"
+
+	for _, tokenType := range []simplexer.TokenType{
+		simplexer.NewCompiledTokenType([]simplexer.TokenType{heredoc}),
+		simplexer.NewStateTokenType(heredoc, simplexer.StateSwitch, simplexer.DefaultState),
+	} {
+		lexer := simplexer.NewLexer(iotest.HalfReader(strings.NewReader(input)))
+		lexer.TokenTypes = append([]simplexer.TokenType{tokenType}, lexer.TokenTypes...)
+
+		tokens, err := lexer.Tokens()
+		if err != nil {
+			t.Fatalf("failed to scan: %s", err)
+		}
+
+		if len(tokens) != 3 || tokens[1].Literal != "<<END\n"+body+"\nEND" || tokens[2].Literal != "echo" {
+			t.Errorf("%s: excepted cat, heredoc and echo but got %v", tokenType, tokens)
+		}
+	}
+}
+
+func TestRuneCursor(t *testing.T) {
+	var steps []string
+
+	m := simplexer.CursorFunc(func(c *simplexer.RuneCursor) bool {
+		steps = append(steps, fmt.Sprintf("%c", c.Peek()))
+		steps = append(steps, fmt.Sprintf("%c", c.Next()))
+		steps = append(steps, fmt.Sprint(c.Offset()))
+
+		if !c.Match(simplexer.Literal("b")) {
+			return false
+		}
+		if c.Accept("x") {
+			return false
+		}
+		steps = append(steps, c.Consumed(), c.Rest())
+
+		c.Reset(3)
+		steps = append(steps, fmt.Sprint(c.AcceptFunc(unicode.IsLetter)), fmt.Sprint(c.EOF()))
+		return true
+	})
+
+	length, ok := m("あbc")
+	if length != 5 || !ok {
+		t.Errorf("excepted (5, true) but got (%d, %v)", length, ok)
+	}
+
+	except := "あ,あ,3,あb,c,2,true"
+	if s := strings.Join(steps, ","); s != except {
+		t.Errorf("excepted %#v but got %#v", except, s)
+	}
+}
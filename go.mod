@@ -0,0 +1,3 @@
+module github.com/macrat/simplexer
+
+go 1.23
@@ -0,0 +1,312 @@
+package simplexer
+
+import (
+	"maps"
+	"strings"
+)
+
+// TokenTypes of synthetic tokens for Lexer.Indentation.
+var (
+	IndentTokenType  = NewPatternTokenType(INDENT, nil)
+	DedentTokenType  = NewPatternTokenType(DEDENT, nil)
+	NewlineTokenType = NewPatternTokenType(NEWLINE, nil)
+)
+
+// DefaultBrackets is the default value of Indentation.Brackets.
+var DefaultBrackets = map[string]string{
+	"(": ")",
+	"[": "]",
+	"{": "}",
+}
+
+// TabPolicy is a policy for tabs in indentation.
+type TabPolicy int
+
+const (
+	// ExpandTabs expands tabs to the next tab stop of Lexer.TabWidth, and compares width of indentation.
+	ExpandTabs TabPolicy = iota
+
+	// RejectTabs reports IndentationError if indentation has a tab.
+	RejectTabs
+
+	// StrictTabs compares indentation as string like Python.
+	// Indentation of an inner block has to start with indentation of the outer block,
+	// so mixing tabs and spaces is an error unless it is consistent.
+	StrictTabs
+)
+
+/*
+Indentation is a setting of the offside rule for Lexer.Indentation.
+
+Lexer emits synthetic tokens for indentation-sensitive languages like Python or YAML.
+The token of IndentTokenType is emitted before the first token of a line that indented deeper than the previous block,
+and tokens of DedentTokenType are emitted for each closed block.
+The token of NewlineTokenType is emitted at the end of a line that has tokens.
+Blank lines, and lines that have only trivia comments, are skipped.
+At the end of input, Lexer emits NEWLINE and DEDENTs for all open blocks.
+
+INDENT and DEDENT have an empty literal, and their Position is the first token of the line.
+NEWLINE has "\n" as the literal, or empty literal at the end of input.
+
+Tabs is a TabPolicy for tabs in indentation.
+
+Brackets is a map of open bracket to close bracket.
+Newlines and indentation in brackets are ignored.
+*/
+type Indentation struct {
+	Tabs     TabPolicy
+	Brackets map[string]string
+}
+
+// Make new Indentation with ExpandTabs and a copy of DefaultBrackets.
+func NewIndentation() *Indentation {
+	return &Indentation{
+		Tabs:     ExpandTabs,
+		Brackets: maps.Clone(DefaultBrackets),
+	}
+}
+
+// indentState is a state of Lexer.Indentation in the cursor.
+type indentState struct {
+	stack    []string
+	depth    int
+	midLine  bool
+	hasToken bool
+	dedents  int
+	indent   bool
+}
+
+// newlineSignificant reports whether a newline at the cursor is a NEWLINE token or a trivia for Lexer.Indentation.
+func (l *Lexer) newlineSignificant() bool {
+	return l.Indentation != nil && l.cur.indent.depth == 0
+}
+
+// syntheticToken makes a token at the cursor and consumes it.
+func (l *Lexer) syntheticToken(tt TokenType, literal string) *Token {
+	t := &Token{
+		Type:     tt,
+		Literal:  literal,
+		Position: l.cur.pos,
+	}
+	l.setSpan(t)
+	l.consume(t)
+	return t
+}
+
+/*
+offsideToken returns a synthetic token at the cursor, or nil if there is no synthetic token.
+
+Newlines that are not NEWLINE token are consumed as trivia.
+*/
+func (l *Lexer) offsideToken(trivia *[]*Token) (*Token, error) {
+	ind := &l.cur.indent
+
+	if t := l.pendingToken(); t != nil {
+		return t, nil
+	}
+
+	for ind.depth == 0 && strings.HasPrefix(l.rest(), "\n") {
+		ind.midLine = false
+
+		if ind.hasToken {
+			ind.hasToken = false
+			return l.syntheticToken(NewlineTokenType, "\n"), nil
+		}
+
+		l.skip(&Token{Type: NewlineTokenType, Literal: "\n", Position: l.cur.pos}, trivia)
+		if err := l.skipWhitespace(trivia); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.eof && len(l.rest()) == 0 {
+		if ind.hasToken {
+			ind.hasToken = false
+			return l.syntheticToken(NewlineTokenType, ""), nil
+		}
+
+		if len(ind.stack) > 0 {
+			ind.stack = ind.stack[:len(ind.stack)-1]
+			return l.syntheticToken(DedentTokenType, ""), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// indentWidth returns width of indentation with expanding tabs.
+func (l *Lexer) indentWidth(indent string) int {
+	tabWidth := l.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = DefaultTabWidth
+	}
+
+	width := 0
+	for _, c := range indent {
+		if c == '\t' {
+			width = (width/tabWidth + 1) * tabWidth
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+/*
+compareIndent compares indentation with the indentation stack.
+
+Returns 1 if indent is deeper than the current block, 0 if the same, and -n if it closes n blocks.
+ok is false if indent doesn't match to any block.
+*/
+func (l *Lexer) compareIndent(indent string, stack []string) (cmp int, ok bool) {
+	top := ""
+	if len(stack) > 0 {
+		top = stack[len(stack)-1]
+	}
+
+	if l.Indentation.Tabs == StrictTabs {
+		if indent == top {
+			return 0, true
+		}
+		if strings.HasPrefix(indent, top) {
+			return 1, true
+		}
+		for i := len(stack) - 2; i >= -1; i-- {
+			if i < 0 && indent == "" || i >= 0 && stack[i] == indent {
+				return i - len(stack) + 1, true
+			}
+		}
+		return 0, false
+	}
+
+	width := l.indentWidth(indent)
+	topWidth := l.indentWidth(top)
+	switch {
+	case width == topWidth:
+		return 0, true
+	case width > topWidth:
+		return 1, true
+	}
+	for i := len(stack) - 2; i >= -1; i-- {
+		w := 0
+		if i >= 0 {
+			w = l.indentWidth(stack[i])
+		}
+
+		if w == width {
+			return i - len(stack) + 1, true
+		}
+		if w < width {
+			break
+		}
+	}
+	return 0, false
+}
+
+/*
+indentToken checks indentation of the first token of a line, and returns INDENT or DEDENT token if needed.
+
+If the indentation is inconsistent, returns IndentationError, or records it in recovery mode.
+In recovery mode, the line is treated as a new block in the nearest outer block.
+*/
+func (l *Lexer) indentToken() (*Token, error) {
+	ind := &l.cur.indent
+	ind.midLine = true
+
+	head := l.lineHead(l.cur)
+	indent := head[:len(head)-len(strings.TrimLeft(head, " \t"))]
+
+	var indentErr error
+	if l.Indentation.Tabs == RejectTabs && strings.Contains(indent, "\t") {
+		indentErr = IndentationError{
+			Message:  "tab in indentation",
+			Position: l.cur.pos,
+		}
+	}
+
+	cmp, ok := l.compareIndent(indent, ind.stack)
+	if !ok && indentErr == nil {
+		msg := "unindent does not match any outer indentation level"
+		if l.Indentation.Tabs == StrictTabs {
+			msg = "inconsistent use of tabs and spaces in indentation"
+		}
+		indentErr = IndentationError{
+			Message:  msg,
+			Position: l.cur.pos,
+		}
+	}
+
+	if indentErr != nil {
+		if l.Recovery == NoRecovery {
+			return nil, indentErr
+		}
+		if err := l.recordError(indentErr, l.cur.pos); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case !ok:
+		width := l.indentWidth(indent)
+		n := len(ind.stack)
+		for n > 0 && l.indentWidth(ind.stack[n-1]) >= width {
+			n--
+		}
+		ind.dedents = len(ind.stack) - n
+		ind.stack = append(ind.stack[:n:n], indent)
+		ind.indent = true
+	case cmp > 0:
+		ind.stack = append(ind.stack[:len(ind.stack):len(ind.stack)], indent)
+		ind.indent = true
+	case cmp < 0:
+		ind.stack = ind.stack[:len(ind.stack)+cmp]
+		ind.dedents = -cmp
+	}
+
+	if indentErr != nil && l.Recovery == EmitErrorToken {
+		t := l.syntheticToken(ErrorTokenType, "")
+		t.Err = indentErr
+		return t, nil
+	}
+
+	return l.pendingToken(), nil
+}
+
+// pendingToken returns DEDENT or INDENT token that decided by indentToken, or nil if there is no pending token.
+func (l *Lexer) pendingToken() *Token {
+	ind := &l.cur.indent
+
+	switch {
+	case ind.dedents > 0:
+		ind.dedents--
+		return l.syntheticToken(DedentTokenType, "")
+	case ind.indent:
+		ind.indent = false
+		return l.syntheticToken(IndentTokenType, "")
+	}
+	return nil
+}
+
+// trackIndent updates the state of Lexer.Indentation by the consumed token.
+func (l *Lexer) trackIndent(t *Token) {
+	if l.Indentation == nil {
+		return
+	}
+
+	ind := &l.cur.indent
+	ind.hasToken = true
+	ind.midLine = true
+
+	if _, ok := l.Indentation.Brackets[t.Literal]; ok {
+		ind.depth++
+		return
+	}
+	if ind.depth > 0 {
+		for _, close := range l.Indentation.Brackets {
+			if close == t.Literal {
+				ind.depth--
+				return
+			}
+		}
+	}
+}
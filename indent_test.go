@@ -0,0 +1,232 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleIndentation() {
+	input := strings.Join([]string{
+		"if x:",
+		"    y = (1,",
+		"  2)",
+		"",
+		"    if z:",
+		"        w",
+		"q",
+	}, "\n")
+
+	lexer := simplexer.NewLexer(strings.NewReader(input))
+	lexer.Indentation = simplexer.NewIndentation()
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%d:%d %s\n", token.Position.Line, token.Position.Column, token)
+	}
+
+	// Output:
+	// 0:0 IDENT("if")
+	// 0:3 IDENT("x")
+	// 0:4 OTHER(":")
+	// 0:5 NEWLINE("\n")
+	// 1:4 INDENT("")
+	// 1:4 IDENT("y")
+	// 1:6 OTHER("=")
+	// 1:8 OTHER("(")
+	// 1:9 NUMBER("1")
+	// 1:10 OTHER(",")
+	// 2:2 NUMBER("2")
+	// 2:3 OTHER(")")
+	// 2:4 NEWLINE("\n")
+	// 4:4 IDENT("if")
+	// 4:7 IDENT("z")
+	// 4:8 OTHER(":")
+	// 4:9 NEWLINE("\n")
+	// 5:8 INDENT("")
+	// 5:8 IDENT("w")
+	// 5:9 NEWLINE("\n")
+	// 6:0 DEDENT("")
+	// 6:0 DEDENT("")
+	// 6:0 IDENT("q")
+	// 6:1 NEWLINE("")
+}
+
+func indentLexer(input string, tabs simplexer.TabPolicy) *simplexer.Lexer {
+	lexer := simplexer.NewLexer(strings.NewReader(input))
+	lexer.Indentation = simplexer.NewIndentation()
+	lexer.Indentation.Tabs = tabs
+
+	comment := simplexer.NewLineCommentTokenType(simplexer.COMMENT, "#")
+	comment.Trivia = true
+	lexer.TokenTypes = append([]simplexer.TokenType{comment}, lexer.TokenTypes...)
+
+	return lexer
+}
+
+func indentString(tokens []*simplexer.Token) string {
+	ss := make([]string, len(tokens))
+	for i, t := range tokens {
+		switch t.Type.GetID() {
+		case simplexer.INDENT, simplexer.DEDENT, simplexer.NEWLINE:
+			ss[i] = t.Type.GetID().String()
+		case simplexer.ERROR:
+			ss[i] = "ERROR(" + t.Literal + ")"
+		default:
+			ss[i] = t.Literal
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+func TestIndentation(t *testing.T) {
+	tests := []struct {
+		Input  string
+		Output string
+	}{
+		{"", ""},
+		{"a", "a NEWLINE"},
+		{"a\n", "a NEWLINE"},
+		{"\n\n  \na\n  \n\n", "a NEWLINE"},
+		{"a\n  b", "a NEWLINE INDENT b NEWLINE DEDENT"},
+		{"a\n  b\n    c\n  d\ne\n", "a NEWLINE INDENT b NEWLINE INDENT c NEWLINE DEDENT d NEWLINE DEDENT e NEWLINE"},
+		{"a\n  b\n    c\nd", "a NEWLINE INDENT b NEWLINE INDENT c NEWLINE DEDENT DEDENT d NEWLINE"},
+		{"a\n  # comment\n    # comment\n  b", "a NEWLINE INDENT b NEWLINE DEDENT"},
+		{"a # comment\nb", "a NEWLINE b NEWLINE"},
+		{"a [\nb\n  ] (\n  c ) d\ne", "a [ b ] ( c ) d NEWLINE e NEWLINE"},
+		{"a )\n  b", "a ) NEWLINE INDENT b NEWLINE DEDENT"},
+		{"  a\nb", "INDENT a NEWLINE DEDENT b NEWLINE"},
+		{"a\n\tb\n        c", "a NEWLINE INDENT b NEWLINE c NEWLINE DEDENT"},
+	}
+
+	for _, tc := range tests {
+		tokens, err := indentLexer(tc.Input, simplexer.ExpandTabs).Tokens()
+		if err != nil {
+			t.Errorf("%#v: failed to scan: %s", tc.Input, err)
+			continue
+		}
+
+		if s := indentString(tokens); s != tc.Output {
+			t.Errorf("%#v: excepted %#v but got %#v", tc.Input, tc.Output, s)
+		}
+	}
+}
+
+func TestIndentation_errors(t *testing.T) {
+	tests := []struct {
+		Input   string
+		Tabs    simplexer.TabPolicy
+		Output  string
+		Message string
+		Line    int
+		Column  int
+	}{
+		{"a\n    b\n  c", simplexer.ExpandTabs, "a NEWLINE INDENT b NEWLINE", "unindent does not match any outer indentation level", 2, 2},
+		{"a\n\tb", simplexer.RejectTabs, "a NEWLINE", "tab in indentation", 1, 1},
+		{"a\n\tb\n        c", simplexer.StrictTabs, "a NEWLINE INDENT b NEWLINE", "inconsistent use of tabs and spaces in indentation", 2, 8},
+	}
+
+	for _, tc := range tests {
+		tokens, err := indentLexer(tc.Input, tc.Tabs).Tokens()
+
+		if s := indentString(tokens); s != tc.Output {
+			t.Errorf("%#v: excepted %#v but got %#v", tc.Input, tc.Output, s)
+		}
+
+		except := simplexer.IndentationError{
+			Message:  tc.Message,
+			Position: simplexer.Position{Line: tc.Line, Column: tc.Column},
+		}
+		if err != except {
+			t.Errorf("%#v: excepted %#v but got %#v", tc.Input, except, err)
+		}
+	}
+
+	tokens, err := indentLexer("a\n\t b\n\t c", simplexer.StrictTabs).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+	if s := indentString(tokens); s != "a NEWLINE INDENT b NEWLINE c NEWLINE DEDENT" {
+		t.Errorf("excepted consistent indentation but got %#v", s)
+	}
+}
+
+func TestIndentation_recovery(t *testing.T) {
+	lexer := indentLexer("a\n    b\n  c\n  d\ne", simplexer.ExpandTabs)
+	lexer.Recovery = simplexer.EmitErrorToken
+
+	tokens, err := lexer.Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	except := "a NEWLINE INDENT b NEWLINE ERROR() DEDENT INDENT c NEWLINE d NEWLINE DEDENT e NEWLINE"
+	if s := indentString(tokens); s != except {
+		t.Errorf("excepted %#v but got %#v", except, s)
+	}
+
+	if errs := lexer.Errors(); len(errs) != 1 || errs[0] != tokens[5].Err {
+		t.Errorf("excepted an error but got %#v", errs)
+	}
+
+	lexer = indentLexer("a\n    b\n  c\n  d\ne", simplexer.ExpandTabs)
+	lexer.Recovery = simplexer.SkipError
+
+	tokens, err = lexer.Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	except = "a NEWLINE INDENT b NEWLINE DEDENT INDENT c NEWLINE d NEWLINE DEDENT e NEWLINE"
+	if s := indentString(tokens); s != except {
+		t.Errorf("excepted %#v but got %#v", except, s)
+	}
+}
+
+func TestIndentation_lossless(t *testing.T) {
+	inputs := []string{
+		"a:\n  b # x\n\n  c (\n d)\n# y\ne\n\n",
+		"  a\n\tb\n",
+	}
+
+	for _, input := range inputs {
+		lexer := indentLexer(input, simplexer.ExpandTabs)
+		lexer.Lossless = true
+
+		tokens, err := lexer.Tokens()
+		if err != nil {
+			t.Errorf("%#v: failed to scan: %s", input, err)
+			continue
+		}
+
+		if output := reproduce(tokens); output != input {
+			t.Errorf("%#v: excepted the same string but got %#v", input, output)
+		}
+	}
+}
+
+func TestNewIndentation_brackets(t *testing.T) {
+	a := simplexer.NewIndentation()
+	a.Brackets["<"] = ">"
+	delete(a.Brackets, "(")
+
+	b := simplexer.NewIndentation()
+	if _, ok := b.Brackets["<"]; ok {
+		t.Errorf("excepted brackets of another Indentation are not changed but got %v", b.Brackets)
+	}
+	if _, ok := b.Brackets["("]; !ok {
+		t.Errorf("excepted brackets of another Indentation are not changed but got %v", b.Brackets)
+	}
+	if len(simplexer.DefaultBrackets) != 3 || simplexer.DefaultBrackets["("] != ")" {
+		t.Errorf("excepted DefaultBrackets is not changed but got %v", simplexer.DefaultBrackets)
+	}
+}
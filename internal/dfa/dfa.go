@@ -0,0 +1,573 @@
+// Package dfa builds DFAs that match some regular expressions at once, in leftmost-first semantics like regexp package.
+package dfa
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Range is a transition of DFA for runes from Lo to Hi.
+type Range struct {
+	Lo, Hi rune
+	Next   int
+}
+
+/*
+DFA is a deterministic finite automaton that matches some rules at once.
+
+State 0 is the start state.
+Accept is the index of the accepted rule for each state, or -1 if the state doesn't accept.
+If some rules accepted in the same state, Accept is the smallest index of them.
+
+Each rule is matched in leftmost-first semantics like regexp package, not the longest match.
+For example, "a|ab" matches only "a" of "ab", and "<.*?>" matches only "<a>" of "<a><b>".
+*/
+type DFA struct {
+	Trans  [][]Range
+	Accept []int
+}
+
+// Match runs DFA on the head of s, and returns the matched rule and the length.
+//
+// If longest is false, returns the rule that has the smallest index, like simplexer.FirstMatch.
+// If longest is true, returns the longest match, like simplexer.LongestMatch.
+// Returns -1 as rule if nothing matched.
+func (d *DFA) Match(s string, longest bool) (rule, length int) {
+	rule = -1
+	state := 0
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		state = d.next(state, r)
+		if state < 0 {
+			break
+		}
+		i += size
+
+		if a := d.Accept[state]; a >= 0 && (longest || rule < 0 || a <= rule) {
+			rule, length = a, i
+		}
+	}
+
+	return rule, length
+}
+
+func (d *DFA) next(state int, r rune) int {
+	trans := d.Trans[state]
+	i := sort.Search(len(trans), func(i int) bool {
+		return trans[i].Hi >= r
+	})
+	if i < len(trans) && trans[i].Lo <= r {
+		return trans[i].Next
+	}
+	return -1
+}
+
+/*
+nfaState is a state of Thompson NFA.
+
+eps are ordered by priority, like threads of regexp package.
+rule is the index of the rule that the state belongs to, or -1 for the start state.
+*/
+type nfaState struct {
+	eps    []int
+	ranges []rune
+	next   int
+	accept int
+	rule   int
+}
+
+type nfa struct {
+	states []nfaState
+}
+
+func (n *nfa) add(ranges []rune, next int) int {
+	n.states = append(n.states, nfaState{ranges: ranges, next: next, accept: -1})
+	return len(n.states) - 1
+}
+
+func (n *nfa) addEps(targets ...int) int {
+	n.states = append(n.states, nfaState{eps: targets, next: -1, accept: -1})
+	return len(n.states) - 1
+}
+
+// out is a dangling edge of a fragment. eps is the index in nfaState.eps, or -1 for nfaState.next.
+type out struct {
+	state int
+	eps   int
+}
+
+// fragment is a part of NFA that has a start state and dangling edges.
+type fragment struct {
+	start int
+	outs  []out
+}
+
+func (n *nfa) patch(f fragment, target int) {
+	for _, o := range f.outs {
+		if o.eps < 0 {
+			n.states[o.state].next = target
+		} else {
+			n.states[o.state].eps[o.eps] = target
+		}
+	}
+}
+
+// empty makes a fragment that matches the empty string.
+func (n *nfa) empty() fragment {
+	s := n.addEps(-1)
+	return fragment{start: s, outs: []out{{s, 0}}}
+}
+
+func (n *nfa) runes(ranges []rune) fragment {
+	s := n.add(ranges, -1)
+	return fragment{start: s, outs: []out{{s, -1}}}
+}
+
+func foldRanges(r rune) []rune {
+	ranges := []rune{r, r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		ranges = append(ranges, f, f)
+	}
+	return ranges
+}
+
+func (n *nfa) build(re *syntax.Regexp) (fragment, error) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return n.empty(), nil
+	case syntax.OpNoMatch:
+		return n.runes(nil), nil
+	case syntax.OpLiteral:
+		subs := make([]*syntax.Regexp, len(re.Rune))
+		for i, r := range re.Rune {
+			ranges := []rune{r, r}
+			if re.Flags&syntax.FoldCase != 0 {
+				ranges = foldRanges(r)
+			}
+			subs[i] = &syntax.Regexp{Op: syntax.OpCharClass, Rune: ranges}
+		}
+		return n.build(&syntax.Regexp{Op: syntax.OpConcat, Sub: subs})
+	case syntax.OpCharClass:
+		return n.runes(re.Rune), nil
+	case syntax.OpAnyCharNotNL:
+		return n.runes([]rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}), nil
+	case syntax.OpAnyChar:
+		return n.runes([]rune{0, unicode.MaxRune}), nil
+	case syntax.OpCapture:
+		return n.build(re.Sub[0])
+	case syntax.OpConcat:
+		if len(re.Sub) == 0 {
+			return n.empty(), nil
+		}
+
+		first, err := n.build(re.Sub[0])
+		if err != nil {
+			return fragment{}, err
+		}
+		last := first
+		for _, sub := range re.Sub[1:] {
+			f, err := n.build(sub)
+			if err != nil {
+				return fragment{}, err
+			}
+			n.patch(last, f.start)
+			last = f
+		}
+		return fragment{start: first.start, outs: last.outs}, nil
+	case syntax.OpAlternate:
+		var starts []int
+		var outs []out
+		for _, sub := range re.Sub {
+			f, err := n.build(sub)
+			if err != nil {
+				return fragment{}, err
+			}
+			starts = append(starts, f.start)
+			outs = append(outs, f.outs...)
+		}
+		return fragment{start: n.addEps(starts...), outs: outs}, nil
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		f, err := n.build(re.Sub[0])
+		if err != nil {
+			return fragment{}, err
+		}
+
+		// Greedy operators prefer to repeat, and non-greedy operators prefer to skip.
+		s := n.addEps(f.start, -1)
+		skip := out{s, 1}
+		if re.Flags&syntax.NonGreedy != 0 {
+			s = n.addEps(-1, f.start)
+			skip = out{s, 0}
+		}
+
+		switch re.Op {
+		case syntax.OpStar:
+			n.patch(f, s)
+			return fragment{start: s, outs: []out{skip}}, nil
+		case syntax.OpPlus:
+			n.patch(f, s)
+			return fragment{start: f.start, outs: []out{skip}}, nil
+		default:
+			return fragment{start: s, outs: append(f.outs, skip)}, nil
+		}
+	default:
+		return fragment{}, fmt.Errorf("unsupported syntax %s", re)
+	}
+}
+
+/*
+ParseRule parses a regular expression for DFA.
+
+The regular expression is always matched from the head of string, so "^" at the head is ignored.
+Other anchors and word boundaries are not supported.
+*/
+func ParseRule(expr string) (*syntax.Regexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	re = re.Simplify()
+
+	if re.Op == syntax.OpBeginText {
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}, nil
+	}
+	if re.Op == syntax.OpConcat && len(re.Sub) > 0 && re.Sub[0].Op == syntax.OpBeginText {
+		re.Sub = re.Sub[1:]
+	}
+
+	return re, nil
+}
+
+/*
+LiteralRule makes a regular expression that matches patterns like simplexer.PatternTokenType.
+
+PatternTokenType selects the first matched pattern, and so does the alternation in leftmost-first semantics.
+Patterns that starts with a former pattern are never selected, so they are removed for making DFA smaller.
+*/
+func LiteralRule(patterns []string) *syntax.Regexp {
+	alt := &syntax.Regexp{Op: syntax.OpAlternate}
+
+	for i, p := range patterns {
+		hidden := false
+		for _, q := range patterns[:i] {
+			if strings.HasPrefix(p, q) {
+				hidden = true
+				break
+			}
+		}
+		if !hidden {
+			alt.Sub = append(alt.Sub, &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune(p)})
+		}
+	}
+
+	if len(alt.Sub) == 0 {
+		return &syntax.Regexp{Op: syntax.OpNoMatch}
+	}
+	return alt
+}
+
+/*
+Build builds a minimized DFA from rules.
+
+The index of rules is used as the priority. A smaller index has a higher priority.
+*/
+func Build(rules []*syntax.Regexp) (*DFA, error) {
+	n := &nfa{}
+
+	var starts []int
+	for i, re := range rules {
+		from := len(n.states)
+
+		f, err := n.build(re)
+		if err != nil {
+			return nil, err
+		}
+
+		accept := n.addEps()
+		n.states[accept].accept = i
+		n.patch(f, accept)
+
+		for s := from; s < len(n.states); s++ {
+			n.states[s].rule = i
+		}
+		starts = append(starts, f.start)
+	}
+	start := n.addEps(starts...)
+	n.states[start].rule = -1
+
+	return minimize(n.determinize(start)), nil
+}
+
+/*
+closure follows epsilon edges from threads in order of priority, and returns consuming states and accepting states in the order.
+
+If a rule accepted, threads of the rule after the accepting state are dropped, because they have lower priority than the match.
+Threads before the accepting state are kept, because they can find a longer match that has higher priority.
+*/
+func (n *nfa) closure(threads []int) []int {
+	seen := make(map[int]bool)
+	matched := make(map[int]bool)
+	var result []int
+
+	var visit func(s int)
+	visit = func(s int) {
+		if s < 0 || seen[s] {
+			return
+		}
+		seen[s] = true
+
+		st := n.states[s]
+		switch {
+		case matched[st.rule]:
+		case st.accept >= 0:
+			matched[st.rule] = true
+			result = append(result, s)
+		case st.eps == nil:
+			result = append(result, s)
+		default:
+			for _, e := range st.eps {
+				visit(e)
+			}
+		}
+	}
+
+	for _, s := range threads {
+		visit(s)
+	}
+	return result
+}
+
+func setKey(set []int) string {
+	var buf strings.Builder
+	for _, s := range set {
+		fmt.Fprintf(&buf, "%d,", s)
+	}
+	return buf.String()
+}
+
+func (n *nfa) determinize(start int) *DFA {
+	d := &DFA{}
+	index := make(map[string]int)
+	var sets [][]int
+
+	// Sets are ordered by priority, so the same states in different order are different states of DFA.
+	add := func(set []int) int {
+		key := setKey(set)
+		if i, ok := index[key]; ok {
+			return i
+		}
+
+		accept := -1
+		for _, s := range set {
+			if a := n.states[s].accept; a >= 0 && (accept < 0 || a < accept) {
+				accept = a
+			}
+		}
+
+		index[key] = len(sets)
+		sets = append(sets, set)
+		d.Trans = append(d.Trans, nil)
+		d.Accept = append(d.Accept, accept)
+		return len(sets) - 1
+	}
+
+	add(n.closure([]int{start}))
+
+	for i := 0; i < len(sets); i++ {
+		var bounds []rune
+		for _, s := range sets[i] {
+			rs := n.states[s].ranges
+			for j := 0; j+1 < len(rs); j += 2 {
+				bounds = append(bounds, rs[j], rs[j+1]+1)
+			}
+		}
+		sort.Slice(bounds, func(a, b int) bool {
+			return bounds[a] < bounds[b]
+		})
+
+		var trans []Range
+		for j := 0; j+1 < len(bounds); j++ {
+			lo, hi := bounds[j], bounds[j+1]-1
+			if lo > hi {
+				continue
+			}
+
+			var targets []int
+			for _, s := range sets[i] {
+				st := n.states[s]
+				for k := 0; k+1 < len(st.ranges); k += 2 {
+					if st.ranges[k] <= lo && hi <= st.ranges[k+1] {
+						targets = append(targets, st.next)
+						break
+					}
+				}
+			}
+			if len(targets) == 0 {
+				continue
+			}
+
+			next := add(n.closure(targets))
+			if l := len(trans); l > 0 && trans[l-1].Next == next && trans[l-1].Hi+1 == lo {
+				trans[l-1].Hi = hi
+			} else {
+				trans = append(trans, Range{Lo: lo, Hi: hi, Next: next})
+			}
+		}
+		d.Trans[i] = trans
+	}
+
+	return d
+}
+
+// minimize merges equivalent states of d by partition refinement.
+func minimize(d *DFA) *DFA {
+	class := make([]int, len(d.Accept))
+	for i, a := range d.Accept {
+		class[i] = a + 1
+	}
+
+	for {
+		index := make(map[string]int)
+		next := make([]int, len(class))
+
+		for i := range class {
+			var key strings.Builder
+			fmt.Fprintf(&key, "%d|", class[i])
+			for _, r := range mergeRanges(d.Trans[i], class) {
+				fmt.Fprintf(&key, "%d-%d:%d,", r.Lo, r.Hi, r.Next)
+			}
+
+			k := key.String()
+			if _, ok := index[k]; !ok {
+				index[k] = len(index)
+			}
+			next[i] = index[k]
+		}
+
+		if len(index) == countClasses(class) {
+			class = next
+			break
+		}
+		class = next
+	}
+
+	// Renumber classes so the start state is 0 and the order is stable.
+	renum := make(map[int]int)
+	order := []int{}
+	for _, c := range class {
+		if _, ok := renum[c]; !ok {
+			renum[c] = len(order)
+			order = append(order, c)
+		}
+	}
+
+	m := &DFA{
+		Trans:  make([][]Range, len(order)),
+		Accept: make([]int, len(order)),
+	}
+	done := make([]bool, len(order))
+	for i, c := range class {
+		s := renum[c]
+		if done[s] {
+			continue
+		}
+		done[s] = true
+
+		m.Accept[s] = d.Accept[i]
+		for _, r := range mergeRanges(d.Trans[i], class) {
+			m.Trans[s] = append(m.Trans[s], Range{Lo: r.Lo, Hi: r.Hi, Next: renum[r.Next]})
+		}
+	}
+
+	return m
+}
+
+// mergeRanges maps targets of ranges into classes, and merges adjacent ranges that have the same class.
+func mergeRanges(trans []Range, class []int) []Range {
+	var result []Range
+	for _, r := range trans {
+		c := class[r.Next]
+		if l := len(result); l > 0 && result[l-1].Next == c && result[l-1].Hi+1 == r.Lo {
+			result[l-1].Hi = r.Hi
+		} else {
+			result = append(result, Range{Lo: r.Lo, Hi: r.Hi, Next: c})
+		}
+	}
+	return result
+}
+
+func countClasses(class []int) int {
+	seen := make(map[int]bool)
+	for _, c := range class {
+		seen[c] = true
+	}
+	return len(seen)
+}
+
+/*
+Classes splits runes into equivalence classes that have the same transitions in all states of d.
+
+Returns ranges of runes that Next is the class, and the transition table that indexed by state and class.
+Runes that not included in ranges have no transition.
+*/
+func (d *DFA) Classes() (ranges []Range, table [][]int) {
+	var bounds []rune
+	for _, trans := range d.Trans {
+		for _, r := range trans {
+			bounds = append(bounds, r.Lo, r.Hi+1)
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i] < bounds[j]
+	})
+
+	index := make(map[string]int)
+	var columns [][]int
+
+	for i := 0; i+1 < len(bounds); i++ {
+		lo, hi := bounds[i], bounds[i+1]-1
+		if lo > hi {
+			continue
+		}
+
+		column := make([]int, len(d.Trans))
+		reachable := false
+		var key strings.Builder
+		for s := range d.Trans {
+			column[s] = d.next(s, lo)
+			reachable = reachable || column[s] >= 0
+			fmt.Fprintf(&key, "%d,", column[s])
+		}
+		if !reachable {
+			continue
+		}
+
+		class, ok := index[key.String()]
+		if !ok {
+			class = len(columns)
+			index[key.String()] = class
+			columns = append(columns, column)
+		}
+
+		if l := len(ranges); l > 0 && ranges[l-1].Next == class && ranges[l-1].Hi+1 == lo {
+			ranges[l-1].Hi = hi
+		} else {
+			ranges = append(ranges, Range{Lo: lo, Hi: hi, Next: class})
+		}
+	}
+
+	table = make([][]int, len(d.Trans))
+	for s := range table {
+		table[s] = make([]int, len(columns))
+		for c, column := range columns {
+			table[s][c] = column[s]
+		}
+	}
+
+	return ranges, table
+}
@@ -0,0 +1,182 @@
+package dfa
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func mustBuild(t *testing.T, exprs ...string) *DFA {
+	t.Helper()
+
+	var rules []*syntax.Regexp
+	for _, expr := range exprs {
+		re, err := ParseRule(expr)
+		if err != nil {
+			t.Fatalf("%#v: failed to parse: %s", expr, err)
+		}
+		rules = append(rules, re)
+	}
+
+	d, err := Build(rules)
+	if err != nil {
+		t.Fatalf("%#v: failed to build: %s", exprs, err)
+	}
+	return d
+}
+
+func TestDFA_Match(t *testing.T) {
+	d := mustBuild(t, "if|else", "[a-z]+", "[0-9]+(\\.[0-9]+)?", "(?i)ab", "\\p{Greek}+")
+
+	tests := []struct {
+		Input   string
+		Longest bool
+		Rule    int
+		Length  int
+	}{
+		{"if x", false, 0, 2},
+		{"iffy", false, 0, 2},
+		{"iffy", true, 1, 4},
+		{"else", true, 0, 4},
+		{"abc", false, 1, 3},
+		{"AB", false, 3, 2},
+		{"aBc", true, 3, 2},
+		{"1.5.", false, 2, 3},
+		{"1.", false, 2, 1},
+		{"αβγ!", false, 4, 6},
+		{"!", false, -1, 0},
+		{"", false, -1, 0},
+	}
+
+	for _, tc := range tests {
+		rule, length := d.Match(tc.Input, tc.Longest)
+		if rule != tc.Rule || length != tc.Length {
+			t.Errorf("%#v: excepted %d,%d but got %d,%d", tc.Input, tc.Rule, tc.Length, rule, length)
+		}
+	}
+}
+
+func TestDFA_random(t *testing.T) {
+	exprs := []string{"[a-c]+", "a(b|c)*d", "x?y+", "[^abc\\n]", "(?s).b", "\\d{2,3}", "é+", "a|ab", "(a|ab)(c|bcd)", "x.*?y", "b+?c?", "(?U)y*x"}
+	d := mustBuild(t, exprs...)
+
+	var res []*regexp.Regexp
+	for _, expr := range exprs {
+		res = append(res, regexp.MustCompile("^(?:"+expr+")"))
+	}
+
+	alphabet := []string{"a", "b", "c", "d", "x", "y", "1", "\n", "é", "\xff"}
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		var buf strings.Builder
+		for n := rnd.Intn(8); n > 0; n-- {
+			buf.WriteString(alphabet[rnd.Intn(len(alphabet))])
+		}
+		s := buf.String()
+
+		exceptRule, exceptLength := -1, 0
+		longestRule, longestLength := -1, 0
+		for j, re := range res {
+			m := re.FindString(s)
+			if !re.MatchString(s) {
+				continue
+			}
+			if exceptRule < 0 {
+				exceptRule, exceptLength = j, len(m)
+			}
+			if longestRule < 0 || len(m) > longestLength {
+				longestRule, longestLength = j, len(m)
+			}
+		}
+
+		if rule, length := d.Match(s, false); rule != exceptRule || length != exceptLength {
+			t.Errorf("%#v: excepted first match %d,%d but got %d,%d", s, exceptRule, exceptLength, rule, length)
+		}
+		if rule, length := d.Match(s, true); rule != longestRule || length != longestLength {
+			t.Errorf("%#v: excepted longest match %d,%d but got %d,%d", s, longestRule, longestLength, rule, length)
+		}
+	}
+}
+
+func TestDFA_leftmostFirst(t *testing.T) {
+	tests := []struct {
+		Expr   string
+		Input  string
+		Length int
+	}{
+		{"a|ab", "ab", 1},
+		{"ab|a", "ab", 2},
+		{"/\\*(?s:.)*?\\*/", "/* a */ x /* b */", 7},
+		{"/\\*(?s:.)*\\*/", "/* a */ x /* b */", 17},
+		{"a*?b", "aaab", 4},
+		{"a+?", "aaa", 1},
+		{"(a|ab)(c|bcd)", "abcd", 4},
+	}
+
+	for _, tc := range tests {
+		d := mustBuild(t, tc.Expr)
+		for _, longest := range []bool{false, true} {
+			if rule, length := d.Match(tc.Input, longest); rule != 0 || length != tc.Length {
+				t.Errorf("%#v %#v: excepted 0,%d but got %d,%d", tc.Expr, tc.Input, tc.Length, rule, length)
+			}
+		}
+	}
+}
+
+func TestDFA_Classes(t *testing.T) {
+	d := mustBuild(t, "[a-z]+", "[0-9]+")
+	classes, table := d.Classes()
+
+	if len(classes) != 2 {
+		t.Fatalf("excepted 2 classes but got %v", classes)
+	}
+	if len(table) != len(d.Trans) {
+		t.Errorf("excepted %d rows but got %d", len(d.Trans), len(table))
+	}
+
+	for _, r := range classes {
+		for s := range d.Trans {
+			if table[s][r.Next] != d.next(s, r.Lo) || table[s][r.Next] != d.next(s, r.Hi) {
+				t.Errorf("class %v: table of state %d is not the same as the DFA", r, s)
+			}
+		}
+	}
+}
+
+func TestLiteralRule(t *testing.T) {
+	d, err := Build([]*syntax.Regexp{LiteralRule([]string{"<", "<=", "==", "="})})
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	tests := []struct {
+		Input  string
+		Length int
+	}{
+		{"<=", 1},
+		{"==", 2},
+		{"=<", 1},
+		{"!", 0},
+	}
+
+	for _, tc := range tests {
+		if _, length := d.Match(tc.Input, false); length != tc.Length {
+			t.Errorf("%#v: excepted %d but got %d", tc.Input, tc.Length, length)
+		}
+	}
+}
+
+func TestBuild_unsupported(t *testing.T) {
+	for _, expr := range []string{"a$", "\\bx", "a^b", "(?m)^a"} {
+		re, err := ParseRule(expr)
+		if err != nil {
+			t.Fatalf("%#v: failed to parse: %s", expr, err)
+		}
+		if _, err := Build([]*syntax.Regexp{re}); err == nil {
+			t.Errorf("%#v: excepted error but got nil", expr)
+		}
+	}
+}
@@ -0,0 +1,746 @@
+// Package gen builds DFAs from simplexer.Spec and generates Go source of table-driven lexers.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/macrat/simplexer"
+	"github.com/macrat/simplexer/internal/dfa"
+)
+
+// reserved is names that used by generated code, so they can not be used as names of TokenID constants.
+var reserved = map[string]bool{
+	"TokenID":           true,
+	"Token":             true,
+	"Position":          true,
+	"Lexer":             true,
+	"NewLexer":          true,
+	"NewLexerString":    true,
+	"UnknownTokenError": true,
+	"StateStackError":   true,
+	"ReaderError":       true,
+}
+
+type tokenName struct {
+	Name string
+	ID   simplexer.TokenID
+}
+
+type ruleData struct {
+	ID     simplexer.TokenID
+	Action string
+	State  int
+}
+
+type stateData struct {
+	Name       string
+	Whitespace *dfa.DFA
+	Tokens     *dfa.DFA
+	Rules      []ruleData
+}
+
+type fileData struct {
+	Package   string
+	Longest   bool
+	Constants []tokenName
+	Names     []tokenName
+	States    []stateData
+}
+
+/*
+Generate generates Go source of a table-driven lexer from spec.
+
+The generated source has no dependencies except the standard library.
+It has TokenID constants for rules that have a name, and Lexer, Token and Position like simplexer.
+
+Rules in spec are compiled into a minimized DFA for each state.
+The DFA matches each regular expression in leftmost-first semantics like the interpreted Lexer,
+so alternations like "a|ab" and non-greedy operators like ".*?" find the same tokens.
+
+The generated Lexer streams the input like the interpreted Lexer, and returns ReaderError after tokens that read before the error.
+
+Anchors except "^" at the head, word boundaries, and rules that match the empty string are not supported.
+Returns simplexer.SpecError for them.
+*/
+func Generate(spec *simplexer.Spec, pkg string) ([]byte, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %#v", pkg)
+	}
+
+	data := fileData{
+		Package: pkg,
+		Longest: spec.Strategy == "longest",
+	}
+
+	stateNames := []string{simplexer.DefaultState}
+	for name := range spec.States {
+		stateNames = append(stateNames, name)
+	}
+	sort.Strings(stateNames[1:])
+
+	stateIndex := make(map[string]int)
+	for i, name := range stateNames {
+		stateIndex[name] = i
+	}
+
+	names := make(map[string]simplexer.TokenID)
+
+	for _, name := range stateNames {
+		prefix := ""
+		whitespace, tokens := spec.Whitespace, spec.Tokens
+		if name != simplexer.DefaultState {
+			prefix = "states." + name + "."
+			whitespace, tokens = spec.States[name].Whitespace, spec.States[name].Tokens
+		}
+
+		state, err := buildState(prefix, whitespace, tokens, stateIndex)
+		if err != nil {
+			return nil, err
+		}
+		state.Name = name
+		data.States = append(data.States, state)
+
+		for _, r := range tokens {
+			if r.Name != "" {
+				names[r.Name] = r.ID
+			}
+		}
+	}
+
+	for name, id := range names {
+		data.Names = append(data.Names, tokenName{Name: name, ID: id})
+	}
+	sort.Slice(data.Names, func(i, j int) bool {
+		if data.Names[i].ID != data.Names[j].ID {
+			return data.Names[i].ID < data.Names[j].ID
+		}
+		return data.Names[i].Name < data.Names[j].Name
+	})
+	for _, n := range data.Names {
+		if token.IsIdentifier(n.Name) && token.IsExported(n.Name) && !reserved[n.Name] {
+			data.Constants = append(data.Constants, n)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %w", err)
+	}
+	return src, nil
+}
+
+// sortRules sorts rules by priority like Spec.NewLexer, and returns the indexes in spec.
+func sortRules(rules []simplexer.RuleSpec) []int {
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rules[order[i]].Priority > rules[order[j]].Priority
+	})
+	return order
+}
+
+func buildState(prefix string, whitespace *simplexer.RuleSpec, tokens []simplexer.RuleSpec, stateIndex map[string]int) (stateData, error) {
+	var state stateData
+
+	switch {
+	case whitespace == nil:
+		d, err := dfa.Build([]*syntax.Regexp{dfa.LiteralRule([]string{" ", "\t", "\r", "\n"})})
+		if err != nil {
+			return state, err
+		}
+		state.Whitespace = d
+	case whitespace.Regexp != "" || len(whitespace.Patterns) > 0:
+		re, err := ruleRegexp(prefix+"whitespace", whitespace)
+		if err != nil {
+			return state, err
+		}
+		d, err := dfa.Build([]*syntax.Regexp{re})
+		if err != nil {
+			return state, simplexer.SpecError{Path: prefix + "whitespace", Message: err.Error()}
+		}
+		if d.Accept[0] >= 0 {
+			return state, simplexer.SpecError{Path: prefix + "whitespace", Message: "matches the empty string"}
+		}
+		state.Whitespace = d
+	}
+
+	var rules []*syntax.Regexp
+	for _, i := range sortRules(tokens) {
+		r := &tokens[i]
+		path := fmt.Sprintf("%stokens[%d]", prefix, i)
+
+		re, err := ruleRegexp(path, r)
+		if err != nil {
+			return state, err
+		}
+		if d, err := dfa.Build([]*syntax.Regexp{re}); err != nil {
+			return state, simplexer.SpecError{Path: path, Message: err.Error()}
+		} else if d.Accept[0] >= 0 {
+			return state, simplexer.SpecError{Path: path, Message: "matches the empty string"}
+		}
+		rules = append(rules, re)
+
+		rule := ruleData{ID: r.ID, Action: "lexNone"}
+		switch {
+		case r.Push != "":
+			rule.Action, rule.State = "lexPush", stateIndex[r.Push]
+		case r.Pop:
+			rule.Action = "lexPop"
+		case r.Switch != "":
+			rule.Action, rule.State = "lexSwitch", stateIndex[r.Switch]
+		}
+		state.Rules = append(state.Rules, rule)
+	}
+
+	d, err := dfa.Build(rules)
+	if err != nil {
+		return state, simplexer.SpecError{Path: prefix + "tokens", Message: err.Error()}
+	}
+	state.Tokens = d
+
+	return state, nil
+}
+
+func ruleRegexp(path string, r *simplexer.RuleSpec) (*syntax.Regexp, error) {
+	if r.Regexp == "" {
+		return dfa.LiteralRule(r.Patterns), nil
+	}
+
+	re, err := dfa.ParseRule(r.Regexp)
+	if err != nil {
+		return nil, simplexer.SpecError{Path: path + ".regexp", Message: err.Error()}
+	}
+	return re, nil
+}
+
+// runeLiteral makes a Go literal of r. Printable ASCII characters are quoted for readability.
+func runeLiteral(r rune) string {
+	if r >= 0x20 && r < 0x7f {
+		return strconv.QuoteRune(r)
+	}
+	return fmt.Sprintf("0x%X", r)
+}
+
+// dfaLiteral makes a Go expression that makes the lexDFA of d.
+func dfaLiteral(d *dfa.DFA) string {
+	if d == nil {
+		return "nil"
+	}
+
+	classes, table := d.Classes()
+
+	var buf strings.Builder
+
+	buf.WriteString("newLexDFA(\n[]int")
+	writeInts(&buf, d.Accept)
+	buf.WriteString(",\n[]lexRange{\n")
+	for _, r := range classes {
+		fmt.Fprintf(&buf, "{%s, %s, %d},\n", runeLiteral(r.Lo), runeLiteral(r.Hi), r.Next)
+	}
+	buf.WriteString("},\n[][]int{\n")
+	for _, row := range table {
+		writeInts(&buf, row)
+		buf.WriteString(",\n")
+	}
+	buf.WriteString("},\n)")
+
+	return buf.String()
+}
+
+func writeInts(buf *strings.Builder, xs []int) {
+	buf.WriteString("{")
+	for i, x := range xs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Itoa(x))
+	}
+	buf.WriteString("}")
+}
+
+var fileTemplate = template.Must(template.New("lexer").Funcs(template.FuncMap{
+	"dfa":   dfaLiteral,
+	"quote": strconv.Quote,
+	"int":   func(id simplexer.TokenID) int { return int(id) },
+}).Parse(`// Code generated by simplexer-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenID is identifier of TokenType.
+type TokenID int
+
+{{if .Constants}}// TokenIDs in the spec.
+const (
+{{range .Constants}}	{{.Name}} TokenID = {{int .ID}}
+{{end}})
+
+{{end}}var lexTokenNames = map[TokenID]string{
+{{range .Names}}	{{int .ID}}: {{quote .Name}},
+{{end}}}
+
+// Get readable string of TokenID.
+func (id TokenID) String() string {
+	if name, ok := lexTokenNames[id]; ok {
+		return name
+	}
+	return "UNKNOWN(" + strconv.Itoa(int(id)) + ")"
+}
+
+// Position in the input. Line and Column are 0-based, and Column is the number of bytes from the head of the line.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Convert to string.
+func (p Position) String() string {
+	return fmt.Sprintf("[line:%d, column:%d]", p.Line, p.Column)
+}
+
+// location returns "line:column" for error messages. Line and column are 1-based.
+func (p Position) location() string {
+	return fmt.Sprintf("%d:%d", p.Line+1, p.Column+1)
+}
+
+func (p Position) shift(s string) Position {
+	if idx := strings.LastIndexByte(s, '\n'); idx >= 0 {
+		p.Line += strings.Count(s, "\n")
+		p.Column = len(s) - idx - 1
+	} else {
+		p.Column += len(s)
+	}
+	return p
+}
+
+/*
+Token is a token that found by Lexer.
+
+End is the position after the token. Offset and EndOffset are byte offsets of the token in the input.
+*/
+type Token struct {
+	ID        TokenID
+	Literal   string
+	Position  Position
+	End       Position
+	Offset    int
+	EndOffset int
+}
+
+// Convert to readable string.
+func (t *Token) String() string {
+	return fmt.Sprintf("%s(%#v)", t.ID, t.Literal)
+}
+
+// The error that returns when found an unknown token. Line is the line that includes the unknown token.
+type UnknownTokenError struct {
+	Literal  string
+	Position Position
+	Line     string
+}
+
+// Get error message as string.
+func (se UnknownTokenError) Error() string {
+	return fmt.Sprintf("%s:UnknownTokenError: %#v", se.Position.location(), se.Literal)
+}
+
+// The error that returns when failed to read from the io.Reader of Lexer.
+type ReaderError struct {
+	Err      error
+	Position Position
+}
+
+// Get error message as string.
+func (re ReaderError) Error() string {
+	return fmt.Sprintf("%s:ReaderError: %s", re.Position.location(), re.Err)
+}
+
+// Unwrap returns the error that returned from io.Reader.
+func (re ReaderError) Unwrap() error {
+	return re.Err
+}
+
+// The error that returns when tried to pop the last state from the state stack.
+type StateStackError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (se StateStackError) Error() string {
+	return fmt.Sprintf("%s:StateStackError: can not pop the last state", se.Position.location())
+}
+
+type lexRange struct {
+	lo, hi rune
+	class  int
+}
+
+/*
+lexDFA is a minimized DFA. State 0 is the start state, and accept is the rule index or -1.
+
+Runes are mapped into classes by classes, and table is the next state that indexed by state and class.
+*/
+type lexDFA struct {
+	accept  []int
+	classes []lexRange
+	table   [][]int
+	ascii   [utf8.RuneSelf]int
+}
+
+func newLexDFA(accept []int, classes []lexRange, table [][]int) *lexDFA {
+	d := &lexDFA{
+		accept:  accept,
+		classes: classes,
+		table:   table,
+	}
+
+	for c := range d.ascii {
+		d.ascii[c] = d.class(rune(c))
+	}
+
+	return d
+}
+
+func (d *lexDFA) class(r rune) int {
+	lo, hi := 0, len(d.classes)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < d.classes[mid].lo:
+			hi = mid
+		case r > d.classes[mid].hi:
+			lo = mid + 1
+		default:
+			return d.classes[mid].class
+		}
+	}
+	return -1
+}
+
+// match returns the matched rule and the length at the head of s. The rule is -1 if nothing matched.
+func (d *lexDFA) match(s string, longest bool) (rule, length int) {
+	rule = -1
+	state := 0
+
+	for i := 0; i < len(s); {
+		var class int
+		if c := s[i]; c < utf8.RuneSelf {
+			class = d.ascii[c]
+			i++
+		} else {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			class = d.class(r)
+			i += size
+		}
+		if class < 0 {
+			break
+		}
+
+		state = d.table[state][class]
+		if state < 0 {
+			break
+		}
+
+		if a := d.accept[state]; a >= 0 && (longest || rule < 0 || a <= rule) {
+			rule, length = a, i
+		}
+	}
+
+	return rule, length
+}
+
+const (
+	lexNone = iota
+	lexPush
+	lexPop
+	lexSwitch
+)
+
+type lexRule struct {
+	id     TokenID
+	action int
+	state  int
+}
+
+type lexState struct {
+	name       string
+	whitespace *lexDFA
+	tokens     *lexDFA
+	rules      []lexRule
+}
+
+const lexLongest = {{.Longest}}
+
+var lexStates = []lexState{
+{{range .States}}	{
+		name: {{quote .Name}},
+		whitespace: {{dfa .Whitespace}},
+		tokens: {{dfa .Tokens}},
+		rules: []lexRule{
+{{range .Rules}}			{ {{int .ID}}, {{.Action}}, {{.State}} },
+{{end}}		},
+	},
+{{end}}}
+
+// Lexer is a table-driven lexer that generated by simplexer-gen.
+type Lexer struct {
+	reader  io.Reader
+	chunk   []byte
+	input   string
+	base    int
+	eof     bool
+	readErr error
+	offset  int
+	pos     Position
+	stack   []int
+}
+
+/*
+Make new Lexer.
+
+Lexer reads from reader when it needs more input, like the Lexer of simplexer.
+If reader returned an error, tokens in the input that read before the error are returned first, and then ReaderError is returned.
+*/
+func NewLexer(reader io.Reader) *Lexer {
+	return &Lexer{
+		reader: reader,
+		stack:  []int{0},
+	}
+}
+
+// Make new Lexer that reads s.
+func NewLexerString(s string) *Lexer {
+	return &Lexer{
+		input: s,
+		eof:   true,
+		stack: []int{0},
+	}
+}
+
+// rest returns the buffered input after the cursor.
+func (l *Lexer) rest() string {
+	return l.input[l.offset-l.base:]
+}
+
+// fill reads from reader until the buffer after the cursor has size bytes or reached to EOF.
+func (l *Lexer) fill(size int) {
+	chunk := l.chunk[:0]
+	emptyReads := 0
+	for !l.eof && len(l.rest())+len(chunk) < size {
+		chunk = slices.Grow(chunk, 2048)
+		n, err := l.reader.Read(chunk[len(chunk):cap(chunk)])
+		chunk = chunk[:len(chunk)+n]
+
+		switch {
+		case err == io.EOF:
+			l.eof = true
+		case err != nil:
+			l.eof = true
+			l.readErr = err
+		case n == 0:
+			if emptyReads++; emptyReads >= 100 {
+				l.eof = true
+				l.readErr = io.ErrNoProgress
+			}
+		}
+	}
+	l.input += string(chunk)
+
+	if cap(chunk) <= 4096 {
+		l.chunk = chunk[:0]
+	}
+}
+
+// advance moves the cursor after s, and drops the buffer before the current line.
+func (l *Lexer) advance(s string) {
+	l.pos = l.pos.shift(s)
+	l.offset += len(s)
+
+	if idx := strings.LastIndexByte(s, '\n'); idx >= 0 {
+		head := l.offset - len(s) + idx + 1
+		l.input = l.input[head-l.base:]
+		l.base = head
+	}
+}
+
+// State returns the name of the current state.
+func (l *Lexer) State() string {
+	return lexStates[l.stack[len(l.stack)-1]].name
+}
+
+func (l *Lexer) skipWhitespace() {
+	whitespace := lexStates[l.stack[len(l.stack)-1]].whitespace
+	if whitespace == nil {
+		return
+	}
+
+	for {
+		l.fill(1024)
+
+		_, n := whitespace.match(l.rest(), false)
+		if n == 0 {
+			return
+		}
+		l.advance(l.rest()[:n])
+	}
+}
+
+/*
+matchToken matches the rules of the current state, and reads more input if the token reached to the end of the buffer.
+
+The buffer might end in the middle of a rune too, so the token is matched again if a partial rune follows it.
+*/
+func (l *Lexer) matchToken() (rule, length int) {
+	state := &lexStates[l.stack[len(l.stack)-1]]
+
+	for {
+		rest := l.rest()
+		rule, length = state.tokens.match(rest, lexLongest)
+		if l.eof {
+			return rule, length
+		}
+		if rule < 0 && (rest == "" || utf8.FullRuneInString(rest)) {
+			return rule, length
+		}
+		if rule >= 0 && length < len(rest) && utf8.FullRuneInString(rest[length:]) {
+			return rule, length
+		}
+
+		l.fill(len(rest) * 2)
+	}
+}
+
+func (l *Lexer) makeError() UnknownTokenError {
+	state := &lexStates[l.stack[len(l.stack)-1]]
+	rest := l.rest()
+
+	literal := rest
+	for shift := range rest {
+		if state.whitespace != nil {
+			if _, n := state.whitespace.match(rest[shift:], false); n > 0 {
+				literal = rest[:shift]
+				break
+			}
+		}
+		if rule, _ := state.tokens.match(rest[shift:], false); rule >= 0 {
+			literal = rest[:shift]
+			break
+		}
+	}
+
+	return UnknownTokenError{
+		Literal:  literal,
+		Position: l.pos,
+		Line:     l.GetLastLine(),
+	}
+}
+
+/*
+GetLastLine returns the line of the cursor, that is the line of the last scanned token or the next line of it.
+
+The line might be a part of the line if the rest of the line is not read yet.
+*/
+func (l *Lexer) GetLastLine() string {
+	line := l.input
+	if idx := strings.IndexByte(line, '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+	return line
+}
+
+// next finds a token at the cursor without consuming it.
+func (l *Lexer) next() (*Token, *lexRule, error) {
+	l.skipWhitespace()
+
+	rest := l.rest()
+	if rest == "" {
+		if l.readErr != nil {
+			return nil, nil, ReaderError{Err: l.readErr, Position: l.pos}
+		}
+		return nil, nil, nil
+	}
+
+	i, n := l.matchToken()
+	if i < 0 {
+		return nil, nil, l.makeError()
+	}
+	rest = l.rest()
+
+	rule := &lexStates[l.stack[len(l.stack)-1]].rules[i]
+	if rule.action == lexPop && len(l.stack) <= 1 {
+		return nil, nil, StateStackError{Position: l.pos}
+	}
+
+	return &Token{
+		ID:        rule.id,
+		Literal:   rest[:n],
+		Position:  l.pos,
+		End:       l.pos.shift(rest[:n]),
+		Offset:    l.offset,
+		EndOffset: l.offset + n,
+	}, rule, nil
+}
+
+/*
+Peek the first token in the input without consuming it.
+
+Returns nil as *Token at the end of input.
+Returns UnknownTokenError if no rule matched, or StateStackError if the token can not pop the state.
+*/
+func (l *Lexer) Peek() (*Token, error) {
+	t, _, err := l.next()
+	return t, err
+}
+
+/*
+Scan will get the first token in the input and consume it.
+
+If the rule of the token changes state, Scan changes the state of Lexer.
+Please read document of Peek about errors.
+*/
+func (l *Lexer) Scan() (*Token, error) {
+	t, rule, err := l.next()
+	if t == nil || err != nil {
+		return nil, err
+	}
+
+	switch rule.action {
+	case lexPush:
+		l.stack = append(l.stack, rule.state)
+	case lexPop:
+		l.stack = l.stack[:len(l.stack)-1]
+	case lexSwitch:
+		l.stack[len(l.stack)-1] = rule.state
+	}
+
+	l.advance(t.Literal)
+
+	return t, nil
+}
+`))
@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func TestGenerate(t *testing.T) {
+	spec := &simplexer.Spec{
+		Tokens: []simplexer.RuleSpec{
+			{Name: "NUMBER", ID: 1, Regexp: "[0-9]+"},
+			{Name: "lower", ID: 2, Regexp: "[a-z]+"},
+			{Name: "Token", ID: 3, Patterns: []string{"+"}},
+		},
+	}
+
+	src, err := Generate(spec, "calc")
+	if err != nil {
+		t.Fatalf("failed to generate: %s", err)
+	}
+
+	for _, except := range []string{"package calc\n", "\tNUMBER TokenID = 1\n", "\t2: \"lower\",\n", "\t3: \"Token\",\n"} {
+		if !strings.Contains(string(src), except) {
+			t.Errorf("excepted to contain %#v", except)
+		}
+	}
+
+	for _, unexcepted := range []string{"lower TokenID", "Token TokenID"} {
+		if strings.Contains(string(src), unexcepted) {
+			t.Errorf("excepted not to contain %#v", unexcepted)
+		}
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := []struct {
+		Spec    simplexer.Spec
+		Package string
+		Error   string
+	}{
+		{
+			simplexer.Spec{Tokens: []simplexer.RuleSpec{{ID: 1, Regexp: "a*"}}},
+			"x",
+			"tokens[0]:SpecError: matches the empty string",
+		},
+		{
+			simplexer.Spec{Tokens: []simplexer.RuleSpec{{ID: 1, Regexp: "a$"}}},
+			"x",
+			"tokens[0]:SpecError: unsupported syntax (?-m:$)",
+		},
+		{
+			simplexer.Spec{
+				Tokens: []simplexer.RuleSpec{{ID: 1, Regexp: "a"}},
+				States: map[string]simplexer.StateSpec{
+					"s": {Whitespace: &simplexer.RuleSpec{Regexp: " *"}, Tokens: []simplexer.RuleSpec{{ID: 1, Regexp: "a"}}},
+				},
+			},
+			"x",
+			"states.s.whitespace:SpecError: matches the empty string",
+		},
+		{
+			simplexer.Spec{Tokens: []simplexer.RuleSpec{{ID: 1}}},
+			"x",
+			"tokens[0]:SpecError: regexp or patterns is required",
+		},
+		{
+			simplexer.Spec{Tokens: []simplexer.RuleSpec{{ID: 1, Regexp: "a"}}},
+			"x-y",
+			"invalid package name \"x-y\"",
+		},
+	}
+
+	for _, tc := range tests {
+		_, err := Generate(&tc.Spec, tc.Package)
+		if err == nil {
+			t.Errorf("excepted error %#v but got nil", tc.Error)
+		} else if err.Error() != tc.Error {
+			t.Errorf("excepted error %#v but got %#v", tc.Error, err.Error())
+		}
+	}
+}
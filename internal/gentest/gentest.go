@@ -0,0 +1,4 @@
+// Package gentest is a lexer that generated by simplexer-gen from spec.json, for testing the generator.
+package gentest
+
+//go:generate go run ../../cmd/simplexer-gen -spec spec.json -package gentest -o lexer_gen.go
@@ -0,0 +1,2271 @@
+// Code generated by simplexer-gen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenID is identifier of TokenType.
+type TokenID int
+
+// TokenIDs in the spec.
+const (
+	COMMENT  TokenID = 1
+	KEYWORD  TokenID = 2
+	SQL      TokenID = 3
+	IDENT    TokenID = 4
+	NUMBER   TokenID = 5
+	OPERATOR TokenID = 6
+	QUOTE    TokenID = 7
+	RAW      TokenID = 8
+	ESCAPE   TokenID = 9
+	TEXT     TokenID = 10
+	CLOSE    TokenID = 11
+	BLOCK    TokenID = 12
+	DOTS     TokenID = 13
+)
+
+var lexTokenNames = map[TokenID]string{
+	1:  "COMMENT",
+	2:  "KEYWORD",
+	3:  "SQL",
+	4:  "IDENT",
+	5:  "NUMBER",
+	6:  "OPERATOR",
+	7:  "QUOTE",
+	8:  "RAW",
+	9:  "ESCAPE",
+	10: "TEXT",
+	11: "CLOSE",
+	12: "BLOCK",
+	13: "DOTS",
+}
+
+// Get readable string of TokenID.
+func (id TokenID) String() string {
+	if name, ok := lexTokenNames[id]; ok {
+		return name
+	}
+	return "UNKNOWN(" + strconv.Itoa(int(id)) + ")"
+}
+
+// Position in the input. Line and Column are 0-based, and Column is the number of bytes from the head of the line.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Convert to string.
+func (p Position) String() string {
+	return fmt.Sprintf("[line:%d, column:%d]", p.Line, p.Column)
+}
+
+// location returns "line:column" for error messages. Line and column are 1-based.
+func (p Position) location() string {
+	return fmt.Sprintf("%d:%d", p.Line+1, p.Column+1)
+}
+
+func (p Position) shift(s string) Position {
+	if idx := strings.LastIndexByte(s, '\n'); idx >= 0 {
+		p.Line += strings.Count(s, "\n")
+		p.Column = len(s) - idx - 1
+	} else {
+		p.Column += len(s)
+	}
+	return p
+}
+
+/*
+Token is a token that found by Lexer.
+
+End is the position after the token. Offset and EndOffset are byte offsets of the token in the input.
+*/
+type Token struct {
+	ID        TokenID
+	Literal   string
+	Position  Position
+	End       Position
+	Offset    int
+	EndOffset int
+}
+
+// Convert to readable string.
+func (t *Token) String() string {
+	return fmt.Sprintf("%s(%#v)", t.ID, t.Literal)
+}
+
+// The error that returns when found an unknown token. Line is the line that includes the unknown token.
+type UnknownTokenError struct {
+	Literal  string
+	Position Position
+	Line     string
+}
+
+// Get error message as string.
+func (se UnknownTokenError) Error() string {
+	return fmt.Sprintf("%s:UnknownTokenError: %#v", se.Position.location(), se.Literal)
+}
+
+// The error that returns when failed to read from the io.Reader of Lexer.
+type ReaderError struct {
+	Err      error
+	Position Position
+}
+
+// Get error message as string.
+func (re ReaderError) Error() string {
+	return fmt.Sprintf("%s:ReaderError: %s", re.Position.location(), re.Err)
+}
+
+// Unwrap returns the error that returned from io.Reader.
+func (re ReaderError) Unwrap() error {
+	return re.Err
+}
+
+// The error that returns when tried to pop the last state from the state stack.
+type StateStackError struct {
+	Position Position
+}
+
+// Get error message as string.
+func (se StateStackError) Error() string {
+	return fmt.Sprintf("%s:StateStackError: can not pop the last state", se.Position.location())
+}
+
+type lexRange struct {
+	lo, hi rune
+	class  int
+}
+
+/*
+lexDFA is a minimized DFA. State 0 is the start state, and accept is the rule index or -1.
+
+Runes are mapped into classes by classes, and table is the next state that indexed by state and class.
+*/
+type lexDFA struct {
+	accept  []int
+	classes []lexRange
+	table   [][]int
+	ascii   [utf8.RuneSelf]int
+}
+
+func newLexDFA(accept []int, classes []lexRange, table [][]int) *lexDFA {
+	d := &lexDFA{
+		accept:  accept,
+		classes: classes,
+		table:   table,
+	}
+
+	for c := range d.ascii {
+		d.ascii[c] = d.class(rune(c))
+	}
+
+	return d
+}
+
+func (d *lexDFA) class(r rune) int {
+	lo, hi := 0, len(d.classes)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < d.classes[mid].lo:
+			hi = mid
+		case r > d.classes[mid].hi:
+			lo = mid + 1
+		default:
+			return d.classes[mid].class
+		}
+	}
+	return -1
+}
+
+// match returns the matched rule and the length at the head of s. The rule is -1 if nothing matched.
+func (d *lexDFA) match(s string, longest bool) (rule, length int) {
+	rule = -1
+	state := 0
+
+	for i := 0; i < len(s); {
+		var class int
+		if c := s[i]; c < utf8.RuneSelf {
+			class = d.ascii[c]
+			i++
+		} else {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			class = d.class(r)
+			i += size
+		}
+		if class < 0 {
+			break
+		}
+
+		state = d.table[state][class]
+		if state < 0 {
+			break
+		}
+
+		if a := d.accept[state]; a >= 0 && (longest || rule < 0 || a <= rule) {
+			rule, length = a, i
+		}
+	}
+
+	return rule, length
+}
+
+const (
+	lexNone = iota
+	lexPush
+	lexPop
+	lexSwitch
+)
+
+type lexRule struct {
+	id     TokenID
+	action int
+	state  int
+}
+
+type lexState struct {
+	name       string
+	whitespace *lexDFA
+	tokens     *lexDFA
+	rules      []lexRule
+}
+
+const lexLongest = false
+
+var lexStates = []lexState{
+	{
+		name: "INITIAL",
+		whitespace: newLexDFA(
+			[]int{-1, 0},
+			[]lexRange{
+				{0x9, 0xA, 0},
+				{0xD, 0xD, 0},
+				{' ', ' ', 0},
+			},
+			[][]int{
+				{1},
+				{-1},
+			},
+		),
+		tokens: newLexDFA(
+			[]int{-1, 8, 7, 2, 7, 6, 7, 5, 5, 5, 9, 5, 5, 5, -1, 0, -1, -1, 5, 5, 5, 5, 3, -1, 6, -1, 6, 5, 5, 5, 5, 1, 4, 5, 5},
+			[]lexRange{
+				{0x0, 0x9, 0},
+				{0xA, 0xA, 1},
+				{0xB, '!', 0},
+				{'"', '"', 2},
+				{'#', '\'', 0},
+				{'(', ')', 3},
+				{'*', '*', 4},
+				{'+', '+', 5},
+				{',', ',', 0},
+				{'-', '-', 5},
+				{'.', '.', 6},
+				{'/', '/', 7},
+				{'0', '9', 8},
+				{':', ':', 0},
+				{';', '<', 3},
+				{'=', '=', 9},
+				{'>', '@', 0},
+				{'A', 'B', 10},
+				{'C', 'C', 11},
+				{'D', 'D', 10},
+				{'E', 'E', 12},
+				{'F', 'F', 13},
+				{'G', 'K', 10},
+				{'L', 'L', 14},
+				{'M', 'M', 15},
+				{'N', 'N', 10},
+				{'O', 'O', 16},
+				{'P', 'Q', 10},
+				{'R', 'R', 17},
+				{'S', 'S', 18},
+				{'T', 'T', 19},
+				{'U', 'Z', 10},
+				{'[', '^', 0},
+				{'_', '_', 10},
+				{'`', '`', 20},
+				{'a', 'b', 10},
+				{'c', 'c', 21},
+				{'d', 'd', 10},
+				{'e', 'e', 22},
+				{'f', 'f', 23},
+				{'g', 'h', 10},
+				{'i', 'i', 24},
+				{'j', 'k', 10},
+				{'l', 'l', 25},
+				{'m', 'm', 15},
+				{'n', 'n', 26},
+				{'o', 'o', 16},
+				{'p', 'q', 10},
+				{'r', 'r', 17},
+				{'s', 's', 27},
+				{'t', 't', 19},
+				{'u', 'u', 28},
+				{'v', 'z', 10},
+				{'{', '{', 3},
+				{'|', '|', 0},
+				{'}', '}', 3},
+				{'~', 0xA9, 0},
+				{0xAA, 0xAA, 10},
+				{0xAB, 0xB1, 0},
+				{0xB2, 0xB3, 29},
+				{0xB4, 0xB4, 0},
+				{0xB5, 0xB5, 10},
+				{0xB6, 0xB8, 0},
+				{0xB9, 0xB9, 29},
+				{0xBA, 0xBA, 10},
+				{0xBB, 0xBB, 0},
+				{0xBC, 0xBE, 29},
+				{0xBF, 0xBF, 0},
+				{0xC0, 0xD6, 10},
+				{0xD7, 0xD7, 0},
+				{0xD8, 0xF6, 10},
+				{0xF7, 0xF7, 0},
+				{0xF8, 0x17E, 10},
+				{0x17F, 0x17F, 18},
+				{0x180, 0x2C1, 10},
+				{0x2C2, 0x2C5, 0},
+				{0x2C6, 0x2D1, 10},
+				{0x2D2, 0x2DF, 0},
+				{0x2E0, 0x2E4, 10},
+				{0x2E5, 0x2EB, 0},
+				{0x2EC, 0x2EC, 10},
+				{0x2ED, 0x2ED, 0},
+				{0x2EE, 0x2EE, 10},
+				{0x2EF, 0x36F, 0},
+				{0x370, 0x374, 10},
+				{0x375, 0x375, 0},
+				{0x376, 0x377, 10},
+				{0x378, 0x379, 0},
+				{0x37A, 0x37D, 10},
+				{0x37E, 0x37E, 0},
+				{0x37F, 0x37F, 10},
+				{0x380, 0x385, 0},
+				{0x386, 0x386, 10},
+				{0x387, 0x387, 0},
+				{0x388, 0x38A, 10},
+				{0x38B, 0x38B, 0},
+				{0x38C, 0x38C, 10},
+				{0x38D, 0x38D, 0},
+				{0x38E, 0x3A1, 10},
+				{0x3A2, 0x3A2, 0},
+				{0x3A3, 0x3F5, 10},
+				{0x3F6, 0x3F6, 0},
+				{0x3F7, 0x481, 10},
+				{0x482, 0x489, 0},
+				{0x48A, 0x52F, 10},
+				{0x530, 0x530, 0},
+				{0x531, 0x556, 10},
+				{0x557, 0x558, 0},
+				{0x559, 0x559, 10},
+				{0x55A, 0x55F, 0},
+				{0x560, 0x588, 10},
+				{0x589, 0x5CF, 0},
+				{0x5D0, 0x5EA, 10},
+				{0x5EB, 0x5EE, 0},
+				{0x5EF, 0x5F2, 10},
+				{0x5F3, 0x61F, 0},
+				{0x620, 0x64A, 10},
+				{0x64B, 0x65F, 0},
+				{0x660, 0x669, 29},
+				{0x66A, 0x66D, 0},
+				{0x66E, 0x66F, 10},
+				{0x670, 0x670, 0},
+				{0x671, 0x6D3, 10},
+				{0x6D4, 0x6D4, 0},
+				{0x6D5, 0x6D5, 10},
+				{0x6D6, 0x6E4, 0},
+				{0x6E5, 0x6E6, 10},
+				{0x6E7, 0x6ED, 0},
+				{0x6EE, 0x6EF, 10},
+				{0x6F0, 0x6F9, 29},
+				{0x6FA, 0x6FC, 10},
+				{0x6FD, 0x6FE, 0},
+				{0x6FF, 0x6FF, 10},
+				{0x700, 0x70F, 0},
+				{0x710, 0x710, 10},
+				{0x711, 0x711, 0},
+				{0x712, 0x72F, 10},
+				{0x730, 0x74C, 0},
+				{0x74D, 0x7A5, 10},
+				{0x7A6, 0x7B0, 0},
+				{0x7B1, 0x7B1, 10},
+				{0x7B2, 0x7BF, 0},
+				{0x7C0, 0x7C9, 29},
+				{0x7CA, 0x7EA, 10},
+				{0x7EB, 0x7F3, 0},
+				{0x7F4, 0x7F5, 10},
+				{0x7F6, 0x7F9, 0},
+				{0x7FA, 0x7FA, 10},
+				{0x7FB, 0x7FF, 0},
+				{0x800, 0x815, 10},
+				{0x816, 0x819, 0},
+				{0x81A, 0x81A, 10},
+				{0x81B, 0x823, 0},
+				{0x824, 0x824, 10},
+				{0x825, 0x827, 0},
+				{0x828, 0x828, 10},
+				{0x829, 0x83F, 0},
+				{0x840, 0x858, 10},
+				{0x859, 0x85F, 0},
+				{0x860, 0x86A, 10},
+				{0x86B, 0x86F, 0},
+				{0x870, 0x887, 10},
+				{0x888, 0x888, 0},
+				{0x889, 0x88F, 10},
+				{0x890, 0x89F, 0},
+				{0x8A0, 0x8C9, 10},
+				{0x8CA, 0x903, 0},
+				{0x904, 0x939, 10},
+				{0x93A, 0x93C, 0},
+				{0x93D, 0x93D, 10},
+				{0x93E, 0x94F, 0},
+				{0x950, 0x950, 10},
+				{0x951, 0x957, 0},
+				{0x958, 0x961, 10},
+				{0x962, 0x965, 0},
+				{0x966, 0x96F, 29},
+				{0x970, 0x970, 0},
+				{0x971, 0x980, 10},
+				{0x981, 0x984, 0},
+				{0x985, 0x98C, 10},
+				{0x98D, 0x98E, 0},
+				{0x98F, 0x990, 10},
+				{0x991, 0x992, 0},
+				{0x993, 0x9A8, 10},
+				{0x9A9, 0x9A9, 0},
+				{0x9AA, 0x9B0, 10},
+				{0x9B1, 0x9B1, 0},
+				{0x9B2, 0x9B2, 10},
+				{0x9B3, 0x9B5, 0},
+				{0x9B6, 0x9B9, 10},
+				{0x9BA, 0x9BC, 0},
+				{0x9BD, 0x9BD, 10},
+				{0x9BE, 0x9CD, 0},
+				{0x9CE, 0x9CE, 10},
+				{0x9CF, 0x9DB, 0},
+				{0x9DC, 0x9DD, 10},
+				{0x9DE, 0x9DE, 0},
+				{0x9DF, 0x9E1, 10},
+				{0x9E2, 0x9E5, 0},
+				{0x9E6, 0x9EF, 29},
+				{0x9F0, 0x9F1, 10},
+				{0x9F2, 0x9F3, 0},
+				{0x9F4, 0x9F9, 29},
+				{0x9FA, 0x9FB, 0},
+				{0x9FC, 0x9FC, 10},
+				{0x9FD, 0xA04, 0},
+				{0xA05, 0xA0A, 10},
+				{0xA0B, 0xA0E, 0},
+				{0xA0F, 0xA10, 10},
+				{0xA11, 0xA12, 0},
+				{0xA13, 0xA28, 10},
+				{0xA29, 0xA29, 0},
+				{0xA2A, 0xA30, 10},
+				{0xA31, 0xA31, 0},
+				{0xA32, 0xA33, 10},
+				{0xA34, 0xA34, 0},
+				{0xA35, 0xA36, 10},
+				{0xA37, 0xA37, 0},
+				{0xA38, 0xA39, 10},
+				{0xA3A, 0xA58, 0},
+				{0xA59, 0xA5C, 10},
+				{0xA5D, 0xA5D, 0},
+				{0xA5E, 0xA5E, 10},
+				{0xA5F, 0xA65, 0},
+				{0xA66, 0xA6F, 29},
+				{0xA70, 0xA71, 0},
+				{0xA72, 0xA74, 10},
+				{0xA75, 0xA84, 0},
+				{0xA85, 0xA8D, 10},
+				{0xA8E, 0xA8E, 0},
+				{0xA8F, 0xA91, 10},
+				{0xA92, 0xA92, 0},
+				{0xA93, 0xAA8, 10},
+				{0xAA9, 0xAA9, 0},
+				{0xAAA, 0xAB0, 10},
+				{0xAB1, 0xAB1, 0},
+				{0xAB2, 0xAB3, 10},
+				{0xAB4, 0xAB4, 0},
+				{0xAB5, 0xAB9, 10},
+				{0xABA, 0xABC, 0},
+				{0xABD, 0xABD, 10},
+				{0xABE, 0xACF, 0},
+				{0xAD0, 0xAD0, 10},
+				{0xAD1, 0xADF, 0},
+				{0xAE0, 0xAE1, 10},
+				{0xAE2, 0xAE5, 0},
+				{0xAE6, 0xAEF, 29},
+				{0xAF0, 0xAF8, 0},
+				{0xAF9, 0xAF9, 10},
+				{0xAFA, 0xB04, 0},
+				{0xB05, 0xB0C, 10},
+				{0xB0D, 0xB0E, 0},
+				{0xB0F, 0xB10, 10},
+				{0xB11, 0xB12, 0},
+				{0xB13, 0xB28, 10},
+				{0xB29, 0xB29, 0},
+				{0xB2A, 0xB30, 10},
+				{0xB31, 0xB31, 0},
+				{0xB32, 0xB33, 10},
+				{0xB34, 0xB34, 0},
+				{0xB35, 0xB39, 10},
+				{0xB3A, 0xB3C, 0},
+				{0xB3D, 0xB3D, 10},
+				{0xB3E, 0xB5B, 0},
+				{0xB5C, 0xB5D, 10},
+				{0xB5E, 0xB5E, 0},
+				{0xB5F, 0xB61, 10},
+				{0xB62, 0xB65, 0},
+				{0xB66, 0xB6F, 29},
+				{0xB70, 0xB70, 0},
+				{0xB71, 0xB71, 10},
+				{0xB72, 0xB77, 29},
+				{0xB78, 0xB82, 0},
+				{0xB83, 0xB83, 10},
+				{0xB84, 0xB84, 0},
+				{0xB85, 0xB8A, 10},
+				{0xB8B, 0xB8D, 0},
+				{0xB8E, 0xB90, 10},
+				{0xB91, 0xB91, 0},
+				{0xB92, 0xB95, 10},
+				{0xB96, 0xB98, 0},
+				{0xB99, 0xB9A, 10},
+				{0xB9B, 0xB9B, 0},
+				{0xB9C, 0xB9C, 10},
+				{0xB9D, 0xB9D, 0},
+				{0xB9E, 0xB9F, 10},
+				{0xBA0, 0xBA2, 0},
+				{0xBA3, 0xBA4, 10},
+				{0xBA5, 0xBA7, 0},
+				{0xBA8, 0xBAA, 10},
+				{0xBAB, 0xBAD, 0},
+				{0xBAE, 0xBB9, 10},
+				{0xBBA, 0xBCF, 0},
+				{0xBD0, 0xBD0, 10},
+				{0xBD1, 0xBE5, 0},
+				{0xBE6, 0xBF2, 29},
+				{0xBF3, 0xC04, 0},
+				{0xC05, 0xC0C, 10},
+				{0xC0D, 0xC0D, 0},
+				{0xC0E, 0xC10, 10},
+				{0xC11, 0xC11, 0},
+				{0xC12, 0xC28, 10},
+				{0xC29, 0xC29, 0},
+				{0xC2A, 0xC39, 10},
+				{0xC3A, 0xC3C, 0},
+				{0xC3D, 0xC3D, 10},
+				{0xC3E, 0xC57, 0},
+				{0xC58, 0xC5A, 10},
+				{0xC5B, 0xC5B, 0},
+				{0xC5C, 0xC5D, 10},
+				{0xC5E, 0xC5F, 0},
+				{0xC60, 0xC61, 10},
+				{0xC62, 0xC65, 0},
+				{0xC66, 0xC6F, 29},
+				{0xC70, 0xC77, 0},
+				{0xC78, 0xC7E, 29},
+				{0xC7F, 0xC7F, 0},
+				{0xC80, 0xC80, 10},
+				{0xC81, 0xC84, 0},
+				{0xC85, 0xC8C, 10},
+				{0xC8D, 0xC8D, 0},
+				{0xC8E, 0xC90, 10},
+				{0xC91, 0xC91, 0},
+				{0xC92, 0xCA8, 10},
+				{0xCA9, 0xCA9, 0},
+				{0xCAA, 0xCB3, 10},
+				{0xCB4, 0xCB4, 0},
+				{0xCB5, 0xCB9, 10},
+				{0xCBA, 0xCBC, 0},
+				{0xCBD, 0xCBD, 10},
+				{0xCBE, 0xCDB, 0},
+				{0xCDC, 0xCDE, 10},
+				{0xCDF, 0xCDF, 0},
+				{0xCE0, 0xCE1, 10},
+				{0xCE2, 0xCE5, 0},
+				{0xCE6, 0xCEF, 29},
+				{0xCF0, 0xCF0, 0},
+				{0xCF1, 0xCF2, 10},
+				{0xCF3, 0xD03, 0},
+				{0xD04, 0xD0C, 10},
+				{0xD0D, 0xD0D, 0},
+				{0xD0E, 0xD10, 10},
+				{0xD11, 0xD11, 0},
+				{0xD12, 0xD3A, 10},
+				{0xD3B, 0xD3C, 0},
+				{0xD3D, 0xD3D, 10},
+				{0xD3E, 0xD4D, 0},
+				{0xD4E, 0xD4E, 10},
+				{0xD4F, 0xD53, 0},
+				{0xD54, 0xD56, 10},
+				{0xD57, 0xD57, 0},
+				{0xD58, 0xD5E, 29},
+				{0xD5F, 0xD61, 10},
+				{0xD62, 0xD65, 0},
+				{0xD66, 0xD78, 29},
+				{0xD79, 0xD79, 0},
+				{0xD7A, 0xD7F, 10},
+				{0xD80, 0xD84, 0},
+				{0xD85, 0xD96, 10},
+				{0xD97, 0xD99, 0},
+				{0xD9A, 0xDB1, 10},
+				{0xDB2, 0xDB2, 0},
+				{0xDB3, 0xDBB, 10},
+				{0xDBC, 0xDBC, 0},
+				{0xDBD, 0xDBD, 10},
+				{0xDBE, 0xDBF, 0},
+				{0xDC0, 0xDC6, 10},
+				{0xDC7, 0xDE5, 0},
+				{0xDE6, 0xDEF, 29},
+				{0xDF0, 0xE00, 0},
+				{0xE01, 0xE30, 10},
+				{0xE31, 0xE31, 0},
+				{0xE32, 0xE33, 10},
+				{0xE34, 0xE3F, 0},
+				{0xE40, 0xE46, 10},
+				{0xE47, 0xE4F, 0},
+				{0xE50, 0xE59, 29},
+				{0xE5A, 0xE80, 0},
+				{0xE81, 0xE82, 10},
+				{0xE83, 0xE83, 0},
+				{0xE84, 0xE84, 10},
+				{0xE85, 0xE85, 0},
+				{0xE86, 0xE8A, 10},
+				{0xE8B, 0xE8B, 0},
+				{0xE8C, 0xEA3, 10},
+				{0xEA4, 0xEA4, 0},
+				{0xEA5, 0xEA5, 10},
+				{0xEA6, 0xEA6, 0},
+				{0xEA7, 0xEB0, 10},
+				{0xEB1, 0xEB1, 0},
+				{0xEB2, 0xEB3, 10},
+				{0xEB4, 0xEBC, 0},
+				{0xEBD, 0xEBD, 10},
+				{0xEBE, 0xEBF, 0},
+				{0xEC0, 0xEC4, 10},
+				{0xEC5, 0xEC5, 0},
+				{0xEC6, 0xEC6, 10},
+				{0xEC7, 0xECF, 0},
+				{0xED0, 0xED9, 29},
+				{0xEDA, 0xEDB, 0},
+				{0xEDC, 0xEDF, 10},
+				{0xEE0, 0xEFF, 0},
+				{0xF00, 0xF00, 10},
+				{0xF01, 0xF1F, 0},
+				{0xF20, 0xF33, 29},
+				{0xF34, 0xF3F, 0},
+				{0xF40, 0xF47, 10},
+				{0xF48, 0xF48, 0},
+				{0xF49, 0xF6C, 10},
+				{0xF6D, 0xF87, 0},
+				{0xF88, 0xF8C, 10},
+				{0xF8D, 0xFFF, 0},
+				{0x1000, 0x102A, 10},
+				{0x102B, 0x103E, 0},
+				{0x103F, 0x103F, 10},
+				{0x1040, 0x1049, 29},
+				{0x104A, 0x104F, 0},
+				{0x1050, 0x1055, 10},
+				{0x1056, 0x1059, 0},
+				{0x105A, 0x105D, 10},
+				{0x105E, 0x1060, 0},
+				{0x1061, 0x1061, 10},
+				{0x1062, 0x1064, 0},
+				{0x1065, 0x1066, 10},
+				{0x1067, 0x106D, 0},
+				{0x106E, 0x1070, 10},
+				{0x1071, 0x1074, 0},
+				{0x1075, 0x1081, 10},
+				{0x1082, 0x108D, 0},
+				{0x108E, 0x108E, 10},
+				{0x108F, 0x108F, 0},
+				{0x1090, 0x1099, 29},
+				{0x109A, 0x109F, 0},
+				{0x10A0, 0x10C5, 10},
+				{0x10C6, 0x10C6, 0},
+				{0x10C7, 0x10C7, 10},
+				{0x10C8, 0x10CC, 0},
+				{0x10CD, 0x10CD, 10},
+				{0x10CE, 0x10CF, 0},
+				{0x10D0, 0x10FA, 10},
+				{0x10FB, 0x10FB, 0},
+				{0x10FC, 0x1248, 10},
+				{0x1249, 0x1249, 0},
+				{0x124A, 0x124D, 10},
+				{0x124E, 0x124F, 0},
+				{0x1250, 0x1256, 10},
+				{0x1257, 0x1257, 0},
+				{0x1258, 0x1258, 10},
+				{0x1259, 0x1259, 0},
+				{0x125A, 0x125D, 10},
+				{0x125E, 0x125F, 0},
+				{0x1260, 0x1288, 10},
+				{0x1289, 0x1289, 0},
+				{0x128A, 0x128D, 10},
+				{0x128E, 0x128F, 0},
+				{0x1290, 0x12B0, 10},
+				{0x12B1, 0x12B1, 0},
+				{0x12B2, 0x12B5, 10},
+				{0x12B6, 0x12B7, 0},
+				{0x12B8, 0x12BE, 10},
+				{0x12BF, 0x12BF, 0},
+				{0x12C0, 0x12C0, 10},
+				{0x12C1, 0x12C1, 0},
+				{0x12C2, 0x12C5, 10},
+				{0x12C6, 0x12C7, 0},
+				{0x12C8, 0x12D6, 10},
+				{0x12D7, 0x12D7, 0},
+				{0x12D8, 0x1310, 10},
+				{0x1311, 0x1311, 0},
+				{0x1312, 0x1315, 10},
+				{0x1316, 0x1317, 0},
+				{0x1318, 0x135A, 10},
+				{0x135B, 0x1368, 0},
+				{0x1369, 0x137C, 29},
+				{0x137D, 0x137F, 0},
+				{0x1380, 0x138F, 10},
+				{0x1390, 0x139F, 0},
+				{0x13A0, 0x13F5, 10},
+				{0x13F6, 0x13F7, 0},
+				{0x13F8, 0x13FD, 10},
+				{0x13FE, 0x1400, 0},
+				{0x1401, 0x166C, 10},
+				{0x166D, 0x166E, 0},
+				{0x166F, 0x167F, 10},
+				{0x1680, 0x1680, 0},
+				{0x1681, 0x169A, 10},
+				{0x169B, 0x169F, 0},
+				{0x16A0, 0x16EA, 10},
+				{0x16EB, 0x16ED, 0},
+				{0x16EE, 0x16F0, 29},
+				{0x16F1, 0x16F8, 10},
+				{0x16F9, 0x16FF, 0},
+				{0x1700, 0x1711, 10},
+				{0x1712, 0x171E, 0},
+				{0x171F, 0x1731, 10},
+				{0x1732, 0x173F, 0},
+				{0x1740, 0x1751, 10},
+				{0x1752, 0x175F, 0},
+				{0x1760, 0x176C, 10},
+				{0x176D, 0x176D, 0},
+				{0x176E, 0x1770, 10},
+				{0x1771, 0x177F, 0},
+				{0x1780, 0x17B3, 10},
+				{0x17B4, 0x17D6, 0},
+				{0x17D7, 0x17D7, 10},
+				{0x17D8, 0x17DB, 0},
+				{0x17DC, 0x17DC, 10},
+				{0x17DD, 0x17DF, 0},
+				{0x17E0, 0x17E9, 29},
+				{0x17EA, 0x17EF, 0},
+				{0x17F0, 0x17F9, 29},
+				{0x17FA, 0x180F, 0},
+				{0x1810, 0x1819, 29},
+				{0x181A, 0x181F, 0},
+				{0x1820, 0x1878, 10},
+				{0x1879, 0x187F, 0},
+				{0x1880, 0x1884, 10},
+				{0x1885, 0x1886, 0},
+				{0x1887, 0x18A8, 10},
+				{0x18A9, 0x18A9, 0},
+				{0x18AA, 0x18AA, 10},
+				{0x18AB, 0x18AF, 0},
+				{0x18B0, 0x18F5, 10},
+				{0x18F6, 0x18FF, 0},
+				{0x1900, 0x191E, 10},
+				{0x191F, 0x1945, 0},
+				{0x1946, 0x194F, 29},
+				{0x1950, 0x196D, 10},
+				{0x196E, 0x196F, 0},
+				{0x1970, 0x1974, 10},
+				{0x1975, 0x197F, 0},
+				{0x1980, 0x19AB, 10},
+				{0x19AC, 0x19AF, 0},
+				{0x19B0, 0x19C9, 10},
+				{0x19CA, 0x19CF, 0},
+				{0x19D0, 0x19DA, 29},
+				{0x19DB, 0x19FF, 0},
+				{0x1A00, 0x1A16, 10},
+				{0x1A17, 0x1A1F, 0},
+				{0x1A20, 0x1A54, 10},
+				{0x1A55, 0x1A7F, 0},
+				{0x1A80, 0x1A89, 29},
+				{0x1A8A, 0x1A8F, 0},
+				{0x1A90, 0x1A99, 29},
+				{0x1A9A, 0x1AA6, 0},
+				{0x1AA7, 0x1AA7, 10},
+				{0x1AA8, 0x1B04, 0},
+				{0x1B05, 0x1B33, 10},
+				{0x1B34, 0x1B44, 0},
+				{0x1B45, 0x1B4C, 10},
+				{0x1B4D, 0x1B4F, 0},
+				{0x1B50, 0x1B59, 29},
+				{0x1B5A, 0x1B82, 0},
+				{0x1B83, 0x1BA0, 10},
+				{0x1BA1, 0x1BAD, 0},
+				{0x1BAE, 0x1BAF, 10},
+				{0x1BB0, 0x1BB9, 29},
+				{0x1BBA, 0x1BE5, 10},
+				{0x1BE6, 0x1BFF, 0},
+				{0x1C00, 0x1C23, 10},
+				{0x1C24, 0x1C3F, 0},
+				{0x1C40, 0x1C49, 29},
+				{0x1C4A, 0x1C4C, 0},
+				{0x1C4D, 0x1C4F, 10},
+				{0x1C50, 0x1C59, 29},
+				{0x1C5A, 0x1C7D, 10},
+				{0x1C7E, 0x1C7F, 0},
+				{0x1C80, 0x1C8A, 10},
+				{0x1C8B, 0x1C8F, 0},
+				{0x1C90, 0x1CBA, 10},
+				{0x1CBB, 0x1CBC, 0},
+				{0x1CBD, 0x1CBF, 10},
+				{0x1CC0, 0x1CE8, 0},
+				{0x1CE9, 0x1CEC, 10},
+				{0x1CED, 0x1CED, 0},
+				{0x1CEE, 0x1CF3, 10},
+				{0x1CF4, 0x1CF4, 0},
+				{0x1CF5, 0x1CF6, 10},
+				{0x1CF7, 0x1CF9, 0},
+				{0x1CFA, 0x1CFA, 10},
+				{0x1CFB, 0x1CFF, 0},
+				{0x1D00, 0x1DBF, 10},
+				{0x1DC0, 0x1DFF, 0},
+				{0x1E00, 0x1F15, 10},
+				{0x1F16, 0x1F17, 0},
+				{0x1F18, 0x1F1D, 10},
+				{0x1F1E, 0x1F1F, 0},
+				{0x1F20, 0x1F45, 10},
+				{0x1F46, 0x1F47, 0},
+				{0x1F48, 0x1F4D, 10},
+				{0x1F4E, 0x1F4F, 0},
+				{0x1F50, 0x1F57, 10},
+				{0x1F58, 0x1F58, 0},
+				{0x1F59, 0x1F59, 10},
+				{0x1F5A, 0x1F5A, 0},
+				{0x1F5B, 0x1F5B, 10},
+				{0x1F5C, 0x1F5C, 0},
+				{0x1F5D, 0x1F5D, 10},
+				{0x1F5E, 0x1F5E, 0},
+				{0x1F5F, 0x1F7D, 10},
+				{0x1F7E, 0x1F7F, 0},
+				{0x1F80, 0x1FB4, 10},
+				{0x1FB5, 0x1FB5, 0},
+				{0x1FB6, 0x1FBC, 10},
+				{0x1FBD, 0x1FBD, 0},
+				{0x1FBE, 0x1FBE, 10},
+				{0x1FBF, 0x1FC1, 0},
+				{0x1FC2, 0x1FC4, 10},
+				{0x1FC5, 0x1FC5, 0},
+				{0x1FC6, 0x1FCC, 10},
+				{0x1FCD, 0x1FCF, 0},
+				{0x1FD0, 0x1FD3, 10},
+				{0x1FD4, 0x1FD5, 0},
+				{0x1FD6, 0x1FDB, 10},
+				{0x1FDC, 0x1FDF, 0},
+				{0x1FE0, 0x1FEC, 10},
+				{0x1FED, 0x1FF1, 0},
+				{0x1FF2, 0x1FF4, 10},
+				{0x1FF5, 0x1FF5, 0},
+				{0x1FF6, 0x1FFC, 10},
+				{0x1FFD, 0x206F, 0},
+				{0x2070, 0x2070, 29},
+				{0x2071, 0x2071, 10},
+				{0x2072, 0x2073, 0},
+				{0x2074, 0x2079, 29},
+				{0x207A, 0x207E, 0},
+				{0x207F, 0x207F, 10},
+				{0x2080, 0x2089, 29},
+				{0x208A, 0x208F, 0},
+				{0x2090, 0x209C, 10},
+				{0x209D, 0x2101, 0},
+				{0x2102, 0x2102, 10},
+				{0x2103, 0x2106, 0},
+				{0x2107, 0x2107, 10},
+				{0x2108, 0x2109, 0},
+				{0x210A, 0x2113, 10},
+				{0x2114, 0x2114, 0},
+				{0x2115, 0x2115, 10},
+				{0x2116, 0x2118, 0},
+				{0x2119, 0x211D, 10},
+				{0x211E, 0x2123, 0},
+				{0x2124, 0x2124, 10},
+				{0x2125, 0x2125, 0},
+				{0x2126, 0x2126, 10},
+				{0x2127, 0x2127, 0},
+				{0x2128, 0x2128, 10},
+				{0x2129, 0x2129, 0},
+				{0x212A, 0x212D, 10},
+				{0x212E, 0x212E, 0},
+				{0x212F, 0x2139, 10},
+				{0x213A, 0x213B, 0},
+				{0x213C, 0x213F, 10},
+				{0x2140, 0x2144, 0},
+				{0x2145, 0x2149, 10},
+				{0x214A, 0x214D, 0},
+				{0x214E, 0x214E, 10},
+				{0x214F, 0x214F, 0},
+				{0x2150, 0x2182, 29},
+				{0x2183, 0x2184, 10},
+				{0x2185, 0x2189, 29},
+				{0x218A, 0x245F, 0},
+				{0x2460, 0x249B, 29},
+				{0x249C, 0x24E9, 0},
+				{0x24EA, 0x24FF, 29},
+				{0x2500, 0x2775, 0},
+				{0x2776, 0x2793, 29},
+				{0x2794, 0x2BFF, 0},
+				{0x2C00, 0x2CE4, 10},
+				{0x2CE5, 0x2CEA, 0},
+				{0x2CEB, 0x2CEE, 10},
+				{0x2CEF, 0x2CF1, 0},
+				{0x2CF2, 0x2CF3, 10},
+				{0x2CF4, 0x2CFC, 0},
+				{0x2CFD, 0x2CFD, 29},
+				{0x2CFE, 0x2CFF, 0},
+				{0x2D00, 0x2D25, 10},
+				{0x2D26, 0x2D26, 0},
+				{0x2D27, 0x2D27, 10},
+				{0x2D28, 0x2D2C, 0},
+				{0x2D2D, 0x2D2D, 10},
+				{0x2D2E, 0x2D2F, 0},
+				{0x2D30, 0x2D67, 10},
+				{0x2D68, 0x2D6E, 0},
+				{0x2D6F, 0x2D6F, 10},
+				{0x2D70, 0x2D7F, 0},
+				{0x2D80, 0x2D96, 10},
+				{0x2D97, 0x2D9F, 0},
+				{0x2DA0, 0x2DA6, 10},
+				{0x2DA7, 0x2DA7, 0},
+				{0x2DA8, 0x2DAE, 10},
+				{0x2DAF, 0x2DAF, 0},
+				{0x2DB0, 0x2DB6, 10},
+				{0x2DB7, 0x2DB7, 0},
+				{0x2DB8, 0x2DBE, 10},
+				{0x2DBF, 0x2DBF, 0},
+				{0x2DC0, 0x2DC6, 10},
+				{0x2DC7, 0x2DC7, 0},
+				{0x2DC8, 0x2DCE, 10},
+				{0x2DCF, 0x2DCF, 0},
+				{0x2DD0, 0x2DD6, 10},
+				{0x2DD7, 0x2DD7, 0},
+				{0x2DD8, 0x2DDE, 10},
+				{0x2DDF, 0x2E2E, 0},
+				{0x2E2F, 0x2E2F, 10},
+				{0x2E30, 0x3004, 0},
+				{0x3005, 0x3006, 10},
+				{0x3007, 0x3007, 29},
+				{0x3008, 0x3020, 0},
+				{0x3021, 0x3029, 29},
+				{0x302A, 0x3030, 0},
+				{0x3031, 0x3035, 10},
+				{0x3036, 0x3037, 0},
+				{0x3038, 0x303A, 29},
+				{0x303B, 0x303C, 10},
+				{0x303D, 0x3040, 0},
+				{0x3041, 0x3096, 10},
+				{0x3097, 0x309C, 0},
+				{0x309D, 0x309F, 10},
+				{0x30A0, 0x30A0, 0},
+				{0x30A1, 0x30FA, 10},
+				{0x30FB, 0x30FB, 0},
+				{0x30FC, 0x30FF, 10},
+				{0x3100, 0x3104, 0},
+				{0x3105, 0x312F, 10},
+				{0x3130, 0x3130, 0},
+				{0x3131, 0x318E, 10},
+				{0x318F, 0x3191, 0},
+				{0x3192, 0x3195, 29},
+				{0x3196, 0x319F, 0},
+				{0x31A0, 0x31BF, 10},
+				{0x31C0, 0x31EF, 0},
+				{0x31F0, 0x31FF, 10},
+				{0x3200, 0x321F, 0},
+				{0x3220, 0x3229, 29},
+				{0x322A, 0x3247, 0},
+				{0x3248, 0x324F, 29},
+				{0x3250, 0x3250, 0},
+				{0x3251, 0x325F, 29},
+				{0x3260, 0x327F, 0},
+				{0x3280, 0x3289, 29},
+				{0x328A, 0x32B0, 0},
+				{0x32B1, 0x32BF, 29},
+				{0x32C0, 0x33FF, 0},
+				{0x3400, 0x4DBF, 10},
+				{0x4DC0, 0x4DFF, 0},
+				{0x4E00, 0xA48C, 10},
+				{0xA48D, 0xA4CF, 0},
+				{0xA4D0, 0xA4FD, 10},
+				{0xA4FE, 0xA4FF, 0},
+				{0xA500, 0xA60C, 10},
+				{0xA60D, 0xA60F, 0},
+				{0xA610, 0xA61F, 10},
+				{0xA620, 0xA629, 29},
+				{0xA62A, 0xA62B, 10},
+				{0xA62C, 0xA63F, 0},
+				{0xA640, 0xA66E, 10},
+				{0xA66F, 0xA67E, 0},
+				{0xA67F, 0xA69D, 10},
+				{0xA69E, 0xA69F, 0},
+				{0xA6A0, 0xA6E5, 10},
+				{0xA6E6, 0xA6EF, 29},
+				{0xA6F0, 0xA716, 0},
+				{0xA717, 0xA71F, 10},
+				{0xA720, 0xA721, 0},
+				{0xA722, 0xA788, 10},
+				{0xA789, 0xA78A, 0},
+				{0xA78B, 0xA7DC, 10},
+				{0xA7DD, 0xA7F0, 0},
+				{0xA7F1, 0xA801, 10},
+				{0xA802, 0xA802, 0},
+				{0xA803, 0xA805, 10},
+				{0xA806, 0xA806, 0},
+				{0xA807, 0xA80A, 10},
+				{0xA80B, 0xA80B, 0},
+				{0xA80C, 0xA822, 10},
+				{0xA823, 0xA82F, 0},
+				{0xA830, 0xA835, 29},
+				{0xA836, 0xA83F, 0},
+				{0xA840, 0xA873, 10},
+				{0xA874, 0xA881, 0},
+				{0xA882, 0xA8B3, 10},
+				{0xA8B4, 0xA8CF, 0},
+				{0xA8D0, 0xA8D9, 29},
+				{0xA8DA, 0xA8F1, 0},
+				{0xA8F2, 0xA8F7, 10},
+				{0xA8F8, 0xA8FA, 0},
+				{0xA8FB, 0xA8FB, 10},
+				{0xA8FC, 0xA8FC, 0},
+				{0xA8FD, 0xA8FE, 10},
+				{0xA8FF, 0xA8FF, 0},
+				{0xA900, 0xA909, 29},
+				{0xA90A, 0xA925, 10},
+				{0xA926, 0xA92F, 0},
+				{0xA930, 0xA946, 10},
+				{0xA947, 0xA95F, 0},
+				{0xA960, 0xA97C, 10},
+				{0xA97D, 0xA983, 0},
+				{0xA984, 0xA9B2, 10},
+				{0xA9B3, 0xA9CE, 0},
+				{0xA9CF, 0xA9CF, 10},
+				{0xA9D0, 0xA9D9, 29},
+				{0xA9DA, 0xA9DF, 0},
+				{0xA9E0, 0xA9E4, 10},
+				{0xA9E5, 0xA9E5, 0},
+				{0xA9E6, 0xA9EF, 10},
+				{0xA9F0, 0xA9F9, 29},
+				{0xA9FA, 0xA9FE, 10},
+				{0xA9FF, 0xA9FF, 0},
+				{0xAA00, 0xAA28, 10},
+				{0xAA29, 0xAA3F, 0},
+				{0xAA40, 0xAA42, 10},
+				{0xAA43, 0xAA43, 0},
+				{0xAA44, 0xAA4B, 10},
+				{0xAA4C, 0xAA4F, 0},
+				{0xAA50, 0xAA59, 29},
+				{0xAA5A, 0xAA5F, 0},
+				{0xAA60, 0xAA76, 10},
+				{0xAA77, 0xAA79, 0},
+				{0xAA7A, 0xAA7A, 10},
+				{0xAA7B, 0xAA7D, 0},
+				{0xAA7E, 0xAAAF, 10},
+				{0xAAB0, 0xAAB0, 0},
+				{0xAAB1, 0xAAB1, 10},
+				{0xAAB2, 0xAAB4, 0},
+				{0xAAB5, 0xAAB6, 10},
+				{0xAAB7, 0xAAB8, 0},
+				{0xAAB9, 0xAABD, 10},
+				{0xAABE, 0xAABF, 0},
+				{0xAAC0, 0xAAC0, 10},
+				{0xAAC1, 0xAAC1, 0},
+				{0xAAC2, 0xAAC2, 10},
+				{0xAAC3, 0xAADA, 0},
+				{0xAADB, 0xAADD, 10},
+				{0xAADE, 0xAADF, 0},
+				{0xAAE0, 0xAAEA, 10},
+				{0xAAEB, 0xAAF1, 0},
+				{0xAAF2, 0xAAF4, 10},
+				{0xAAF5, 0xAB00, 0},
+				{0xAB01, 0xAB06, 10},
+				{0xAB07, 0xAB08, 0},
+				{0xAB09, 0xAB0E, 10},
+				{0xAB0F, 0xAB10, 0},
+				{0xAB11, 0xAB16, 10},
+				{0xAB17, 0xAB1F, 0},
+				{0xAB20, 0xAB26, 10},
+				{0xAB27, 0xAB27, 0},
+				{0xAB28, 0xAB2E, 10},
+				{0xAB2F, 0xAB2F, 0},
+				{0xAB30, 0xAB5A, 10},
+				{0xAB5B, 0xAB5B, 0},
+				{0xAB5C, 0xAB69, 10},
+				{0xAB6A, 0xAB6F, 0},
+				{0xAB70, 0xABE2, 10},
+				{0xABE3, 0xABEF, 0},
+				{0xABF0, 0xABF9, 29},
+				{0xABFA, 0xABFF, 0},
+				{0xAC00, 0xD7A3, 10},
+				{0xD7A4, 0xD7AF, 0},
+				{0xD7B0, 0xD7C6, 10},
+				{0xD7C7, 0xD7CA, 0},
+				{0xD7CB, 0xD7FB, 10},
+				{0xD7FC, 0xF8FF, 0},
+				{0xF900, 0xFA6D, 10},
+				{0xFA6E, 0xFA6F, 0},
+				{0xFA70, 0xFAD9, 10},
+				{0xFADA, 0xFAFF, 0},
+				{0xFB00, 0xFB06, 10},
+				{0xFB07, 0xFB12, 0},
+				{0xFB13, 0xFB17, 10},
+				{0xFB18, 0xFB1C, 0},
+				{0xFB1D, 0xFB1D, 10},
+				{0xFB1E, 0xFB1E, 0},
+				{0xFB1F, 0xFB28, 10},
+				{0xFB29, 0xFB29, 0},
+				{0xFB2A, 0xFB36, 10},
+				{0xFB37, 0xFB37, 0},
+				{0xFB38, 0xFB3C, 10},
+				{0xFB3D, 0xFB3D, 0},
+				{0xFB3E, 0xFB3E, 10},
+				{0xFB3F, 0xFB3F, 0},
+				{0xFB40, 0xFB41, 10},
+				{0xFB42, 0xFB42, 0},
+				{0xFB43, 0xFB44, 10},
+				{0xFB45, 0xFB45, 0},
+				{0xFB46, 0xFBB1, 10},
+				{0xFBB2, 0xFBD2, 0},
+				{0xFBD3, 0xFD3D, 10},
+				{0xFD3E, 0xFD4F, 0},
+				{0xFD50, 0xFD8F, 10},
+				{0xFD90, 0xFD91, 0},
+				{0xFD92, 0xFDC7, 10},
+				{0xFDC8, 0xFDEF, 0},
+				{0xFDF0, 0xFDFB, 10},
+				{0xFDFC, 0xFE6F, 0},
+				{0xFE70, 0xFE74, 10},
+				{0xFE75, 0xFE75, 0},
+				{0xFE76, 0xFEFC, 10},
+				{0xFEFD, 0xFF0F, 0},
+				{0xFF10, 0xFF19, 29},
+				{0xFF1A, 0xFF20, 0},
+				{0xFF21, 0xFF3A, 10},
+				{0xFF3B, 0xFF40, 0},
+				{0xFF41, 0xFF5A, 10},
+				{0xFF5B, 0xFF65, 0},
+				{0xFF66, 0xFFBE, 10},
+				{0xFFBF, 0xFFC1, 0},
+				{0xFFC2, 0xFFC7, 10},
+				{0xFFC8, 0xFFC9, 0},
+				{0xFFCA, 0xFFCF, 10},
+				{0xFFD0, 0xFFD1, 0},
+				{0xFFD2, 0xFFD7, 10},
+				{0xFFD8, 0xFFD9, 0},
+				{0xFFDA, 0xFFDC, 10},
+				{0xFFDD, 0xFFFF, 0},
+				{0x10000, 0x1000B, 10},
+				{0x1000C, 0x1000C, 0},
+				{0x1000D, 0x10026, 10},
+				{0x10027, 0x10027, 0},
+				{0x10028, 0x1003A, 10},
+				{0x1003B, 0x1003B, 0},
+				{0x1003C, 0x1003D, 10},
+				{0x1003E, 0x1003E, 0},
+				{0x1003F, 0x1004D, 10},
+				{0x1004E, 0x1004F, 0},
+				{0x10050, 0x1005D, 10},
+				{0x1005E, 0x1007F, 0},
+				{0x10080, 0x100FA, 10},
+				{0x100FB, 0x10106, 0},
+				{0x10107, 0x10133, 29},
+				{0x10134, 0x1013F, 0},
+				{0x10140, 0x10178, 29},
+				{0x10179, 0x10189, 0},
+				{0x1018A, 0x1018B, 29},
+				{0x1018C, 0x1027F, 0},
+				{0x10280, 0x1029C, 10},
+				{0x1029D, 0x1029F, 0},
+				{0x102A0, 0x102D0, 10},
+				{0x102D1, 0x102E0, 0},
+				{0x102E1, 0x102FB, 29},
+				{0x102FC, 0x102FF, 0},
+				{0x10300, 0x1031F, 10},
+				{0x10320, 0x10323, 29},
+				{0x10324, 0x1032C, 0},
+				{0x1032D, 0x10340, 10},
+				{0x10341, 0x10341, 29},
+				{0x10342, 0x10349, 10},
+				{0x1034A, 0x1034A, 29},
+				{0x1034B, 0x1034F, 0},
+				{0x10350, 0x10375, 10},
+				{0x10376, 0x1037F, 0},
+				{0x10380, 0x1039D, 10},
+				{0x1039E, 0x1039F, 0},
+				{0x103A0, 0x103C3, 10},
+				{0x103C4, 0x103C7, 0},
+				{0x103C8, 0x103CF, 10},
+				{0x103D0, 0x103D0, 0},
+				{0x103D1, 0x103D5, 29},
+				{0x103D6, 0x103FF, 0},
+				{0x10400, 0x1049D, 10},
+				{0x1049E, 0x1049F, 0},
+				{0x104A0, 0x104A9, 29},
+				{0x104AA, 0x104AF, 0},
+				{0x104B0, 0x104D3, 10},
+				{0x104D4, 0x104D7, 0},
+				{0x104D8, 0x104FB, 10},
+				{0x104FC, 0x104FF, 0},
+				{0x10500, 0x10527, 10},
+				{0x10528, 0x1052F, 0},
+				{0x10530, 0x10563, 10},
+				{0x10564, 0x1056F, 0},
+				{0x10570, 0x1057A, 10},
+				{0x1057B, 0x1057B, 0},
+				{0x1057C, 0x1058A, 10},
+				{0x1058B, 0x1058B, 0},
+				{0x1058C, 0x10592, 10},
+				{0x10593, 0x10593, 0},
+				{0x10594, 0x10595, 10},
+				{0x10596, 0x10596, 0},
+				{0x10597, 0x105A1, 10},
+				{0x105A2, 0x105A2, 0},
+				{0x105A3, 0x105B1, 10},
+				{0x105B2, 0x105B2, 0},
+				{0x105B3, 0x105B9, 10},
+				{0x105BA, 0x105BA, 0},
+				{0x105BB, 0x105BC, 10},
+				{0x105BD, 0x105BF, 0},
+				{0x105C0, 0x105F3, 10},
+				{0x105F4, 0x105FF, 0},
+				{0x10600, 0x10736, 10},
+				{0x10737, 0x1073F, 0},
+				{0x10740, 0x10755, 10},
+				{0x10756, 0x1075F, 0},
+				{0x10760, 0x10767, 10},
+				{0x10768, 0x1077F, 0},
+				{0x10780, 0x10785, 10},
+				{0x10786, 0x10786, 0},
+				{0x10787, 0x107B0, 10},
+				{0x107B1, 0x107B1, 0},
+				{0x107B2, 0x107BA, 10},
+				{0x107BB, 0x107FF, 0},
+				{0x10800, 0x10805, 10},
+				{0x10806, 0x10807, 0},
+				{0x10808, 0x10808, 10},
+				{0x10809, 0x10809, 0},
+				{0x1080A, 0x10835, 10},
+				{0x10836, 0x10836, 0},
+				{0x10837, 0x10838, 10},
+				{0x10839, 0x1083B, 0},
+				{0x1083C, 0x1083C, 10},
+				{0x1083D, 0x1083E, 0},
+				{0x1083F, 0x10855, 10},
+				{0x10856, 0x10857, 0},
+				{0x10858, 0x1085F, 29},
+				{0x10860, 0x10876, 10},
+				{0x10877, 0x10878, 0},
+				{0x10879, 0x1087F, 29},
+				{0x10880, 0x1089E, 10},
+				{0x1089F, 0x108A6, 0},
+				{0x108A7, 0x108AF, 29},
+				{0x108B0, 0x108DF, 0},
+				{0x108E0, 0x108F2, 10},
+				{0x108F3, 0x108F3, 0},
+				{0x108F4, 0x108F5, 10},
+				{0x108F6, 0x108FA, 0},
+				{0x108FB, 0x108FF, 29},
+				{0x10900, 0x10915, 10},
+				{0x10916, 0x1091B, 29},
+				{0x1091C, 0x1091F, 0},
+				{0x10920, 0x10939, 10},
+				{0x1093A, 0x1093F, 0},
+				{0x10940, 0x10959, 10},
+				{0x1095A, 0x1097F, 0},
+				{0x10980, 0x109B7, 10},
+				{0x109B8, 0x109BB, 0},
+				{0x109BC, 0x109BD, 29},
+				{0x109BE, 0x109BF, 10},
+				{0x109C0, 0x109CF, 29},
+				{0x109D0, 0x109D1, 0},
+				{0x109D2, 0x109FF, 29},
+				{0x10A00, 0x10A00, 10},
+				{0x10A01, 0x10A0F, 0},
+				{0x10A10, 0x10A13, 10},
+				{0x10A14, 0x10A14, 0},
+				{0x10A15, 0x10A17, 10},
+				{0x10A18, 0x10A18, 0},
+				{0x10A19, 0x10A35, 10},
+				{0x10A36, 0x10A3F, 0},
+				{0x10A40, 0x10A48, 29},
+				{0x10A49, 0x10A5F, 0},
+				{0x10A60, 0x10A7C, 10},
+				{0x10A7D, 0x10A7E, 29},
+				{0x10A7F, 0x10A7F, 0},
+				{0x10A80, 0x10A9C, 10},
+				{0x10A9D, 0x10A9F, 29},
+				{0x10AA0, 0x10ABF, 0},
+				{0x10AC0, 0x10AC7, 10},
+				{0x10AC8, 0x10AC8, 0},
+				{0x10AC9, 0x10AE4, 10},
+				{0x10AE5, 0x10AEA, 0},
+				{0x10AEB, 0x10AEF, 29},
+				{0x10AF0, 0x10AFF, 0},
+				{0x10B00, 0x10B35, 10},
+				{0x10B36, 0x10B3F, 0},
+				{0x10B40, 0x10B55, 10},
+				{0x10B56, 0x10B57, 0},
+				{0x10B58, 0x10B5F, 29},
+				{0x10B60, 0x10B72, 10},
+				{0x10B73, 0x10B77, 0},
+				{0x10B78, 0x10B7F, 29},
+				{0x10B80, 0x10B91, 10},
+				{0x10B92, 0x10BA8, 0},
+				{0x10BA9, 0x10BAF, 29},
+				{0x10BB0, 0x10BFF, 0},
+				{0x10C00, 0x10C48, 10},
+				{0x10C49, 0x10C7F, 0},
+				{0x10C80, 0x10CB2, 10},
+				{0x10CB3, 0x10CBF, 0},
+				{0x10CC0, 0x10CF2, 10},
+				{0x10CF3, 0x10CF9, 0},
+				{0x10CFA, 0x10CFF, 29},
+				{0x10D00, 0x10D23, 10},
+				{0x10D24, 0x10D2F, 0},
+				{0x10D30, 0x10D39, 29},
+				{0x10D3A, 0x10D3F, 0},
+				{0x10D40, 0x10D49, 29},
+				{0x10D4A, 0x10D65, 10},
+				{0x10D66, 0x10D6E, 0},
+				{0x10D6F, 0x10D85, 10},
+				{0x10D86, 0x10E5F, 0},
+				{0x10E60, 0x10E7E, 29},
+				{0x10E7F, 0x10E7F, 0},
+				{0x10E80, 0x10EA9, 10},
+				{0x10EAA, 0x10EAF, 0},
+				{0x10EB0, 0x10EB1, 10},
+				{0x10EB2, 0x10EC1, 0},
+				{0x10EC2, 0x10EC7, 10},
+				{0x10EC8, 0x10EFF, 0},
+				{0x10F00, 0x10F1C, 10},
+				{0x10F1D, 0x10F26, 29},
+				{0x10F27, 0x10F27, 10},
+				{0x10F28, 0x10F2F, 0},
+				{0x10F30, 0x10F45, 10},
+				{0x10F46, 0x10F50, 0},
+				{0x10F51, 0x10F54, 29},
+				{0x10F55, 0x10F6F, 0},
+				{0x10F70, 0x10F81, 10},
+				{0x10F82, 0x10FAF, 0},
+				{0x10FB0, 0x10FC4, 10},
+				{0x10FC5, 0x10FCB, 29},
+				{0x10FCC, 0x10FDF, 0},
+				{0x10FE0, 0x10FF6, 10},
+				{0x10FF7, 0x11002, 0},
+				{0x11003, 0x11037, 10},
+				{0x11038, 0x11051, 0},
+				{0x11052, 0x1106F, 29},
+				{0x11070, 0x11070, 0},
+				{0x11071, 0x11072, 10},
+				{0x11073, 0x11074, 0},
+				{0x11075, 0x11075, 10},
+				{0x11076, 0x11082, 0},
+				{0x11083, 0x110AF, 10},
+				{0x110B0, 0x110CF, 0},
+				{0x110D0, 0x110E8, 10},
+				{0x110E9, 0x110EF, 0},
+				{0x110F0, 0x110F9, 29},
+				{0x110FA, 0x11102, 0},
+				{0x11103, 0x11126, 10},
+				{0x11127, 0x11135, 0},
+				{0x11136, 0x1113F, 29},
+				{0x11140, 0x11143, 0},
+				{0x11144, 0x11144, 10},
+				{0x11145, 0x11146, 0},
+				{0x11147, 0x11147, 10},
+				{0x11148, 0x1114F, 0},
+				{0x11150, 0x11172, 10},
+				{0x11173, 0x11175, 0},
+				{0x11176, 0x11176, 10},
+				{0x11177, 0x11182, 0},
+				{0x11183, 0x111B2, 10},
+				{0x111B3, 0x111C0, 0},
+				{0x111C1, 0x111C4, 10},
+				{0x111C5, 0x111CF, 0},
+				{0x111D0, 0x111D9, 29},
+				{0x111DA, 0x111DA, 10},
+				{0x111DB, 0x111DB, 0},
+				{0x111DC, 0x111DC, 10},
+				{0x111DD, 0x111E0, 0},
+				{0x111E1, 0x111F4, 29},
+				{0x111F5, 0x111FF, 0},
+				{0x11200, 0x11211, 10},
+				{0x11212, 0x11212, 0},
+				{0x11213, 0x1122B, 10},
+				{0x1122C, 0x1123E, 0},
+				{0x1123F, 0x11240, 10},
+				{0x11241, 0x1127F, 0},
+				{0x11280, 0x11286, 10},
+				{0x11287, 0x11287, 0},
+				{0x11288, 0x11288, 10},
+				{0x11289, 0x11289, 0},
+				{0x1128A, 0x1128D, 10},
+				{0x1128E, 0x1128E, 0},
+				{0x1128F, 0x1129D, 10},
+				{0x1129E, 0x1129E, 0},
+				{0x1129F, 0x112A8, 10},
+				{0x112A9, 0x112AF, 0},
+				{0x112B0, 0x112DE, 10},
+				{0x112DF, 0x112EF, 0},
+				{0x112F0, 0x112F9, 29},
+				{0x112FA, 0x11304, 0},
+				{0x11305, 0x1130C, 10},
+				{0x1130D, 0x1130E, 0},
+				{0x1130F, 0x11310, 10},
+				{0x11311, 0x11312, 0},
+				{0x11313, 0x11328, 10},
+				{0x11329, 0x11329, 0},
+				{0x1132A, 0x11330, 10},
+				{0x11331, 0x11331, 0},
+				{0x11332, 0x11333, 10},
+				{0x11334, 0x11334, 0},
+				{0x11335, 0x11339, 10},
+				{0x1133A, 0x1133C, 0},
+				{0x1133D, 0x1133D, 10},
+				{0x1133E, 0x1134F, 0},
+				{0x11350, 0x11350, 10},
+				{0x11351, 0x1135C, 0},
+				{0x1135D, 0x11361, 10},
+				{0x11362, 0x1137F, 0},
+				{0x11380, 0x11389, 10},
+				{0x1138A, 0x1138A, 0},
+				{0x1138B, 0x1138B, 10},
+				{0x1138C, 0x1138D, 0},
+				{0x1138E, 0x1138E, 10},
+				{0x1138F, 0x1138F, 0},
+				{0x11390, 0x113B5, 10},
+				{0x113B6, 0x113B6, 0},
+				{0x113B7, 0x113B7, 10},
+				{0x113B8, 0x113D0, 0},
+				{0x113D1, 0x113D1, 10},
+				{0x113D2, 0x113D2, 0},
+				{0x113D3, 0x113D3, 10},
+				{0x113D4, 0x113FF, 0},
+				{0x11400, 0x11434, 10},
+				{0x11435, 0x11446, 0},
+				{0x11447, 0x1144A, 10},
+				{0x1144B, 0x1144F, 0},
+				{0x11450, 0x11459, 29},
+				{0x1145A, 0x1145E, 0},
+				{0x1145F, 0x11461, 10},
+				{0x11462, 0x1147F, 0},
+				{0x11480, 0x114AF, 10},
+				{0x114B0, 0x114C3, 0},
+				{0x114C4, 0x114C5, 10},
+				{0x114C6, 0x114C6, 0},
+				{0x114C7, 0x114C7, 10},
+				{0x114C8, 0x114CF, 0},
+				{0x114D0, 0x114D9, 29},
+				{0x114DA, 0x1157F, 0},
+				{0x11580, 0x115AE, 10},
+				{0x115AF, 0x115D7, 0},
+				{0x115D8, 0x115DB, 10},
+				{0x115DC, 0x115FF, 0},
+				{0x11600, 0x1162F, 10},
+				{0x11630, 0x11643, 0},
+				{0x11644, 0x11644, 10},
+				{0x11645, 0x1164F, 0},
+				{0x11650, 0x11659, 29},
+				{0x1165A, 0x1167F, 0},
+				{0x11680, 0x116AA, 10},
+				{0x116AB, 0x116B7, 0},
+				{0x116B8, 0x116B8, 10},
+				{0x116B9, 0x116BF, 0},
+				{0x116C0, 0x116C9, 29},
+				{0x116CA, 0x116CF, 0},
+				{0x116D0, 0x116E3, 29},
+				{0x116E4, 0x116FF, 0},
+				{0x11700, 0x1171A, 10},
+				{0x1171B, 0x1172F, 0},
+				{0x11730, 0x1173B, 29},
+				{0x1173C, 0x1173F, 0},
+				{0x11740, 0x11746, 10},
+				{0x11747, 0x117FF, 0},
+				{0x11800, 0x1182B, 10},
+				{0x1182C, 0x1189F, 0},
+				{0x118A0, 0x118DF, 10},
+				{0x118E0, 0x118F2, 29},
+				{0x118F3, 0x118FE, 0},
+				{0x118FF, 0x11906, 10},
+				{0x11907, 0x11908, 0},
+				{0x11909, 0x11909, 10},
+				{0x1190A, 0x1190B, 0},
+				{0x1190C, 0x11913, 10},
+				{0x11914, 0x11914, 0},
+				{0x11915, 0x11916, 10},
+				{0x11917, 0x11917, 0},
+				{0x11918, 0x1192F, 10},
+				{0x11930, 0x1193E, 0},
+				{0x1193F, 0x1193F, 10},
+				{0x11940, 0x11940, 0},
+				{0x11941, 0x11941, 10},
+				{0x11942, 0x1194F, 0},
+				{0x11950, 0x11959, 29},
+				{0x1195A, 0x1199F, 0},
+				{0x119A0, 0x119A7, 10},
+				{0x119A8, 0x119A9, 0},
+				{0x119AA, 0x119D0, 10},
+				{0x119D1, 0x119E0, 0},
+				{0x119E1, 0x119E1, 10},
+				{0x119E2, 0x119E2, 0},
+				{0x119E3, 0x119E3, 10},
+				{0x119E4, 0x119FF, 0},
+				{0x11A00, 0x11A00, 10},
+				{0x11A01, 0x11A0A, 0},
+				{0x11A0B, 0x11A32, 10},
+				{0x11A33, 0x11A39, 0},
+				{0x11A3A, 0x11A3A, 10},
+				{0x11A3B, 0x11A4F, 0},
+				{0x11A50, 0x11A50, 10},
+				{0x11A51, 0x11A5B, 0},
+				{0x11A5C, 0x11A89, 10},
+				{0x11A8A, 0x11A9C, 0},
+				{0x11A9D, 0x11A9D, 10},
+				{0x11A9E, 0x11AAF, 0},
+				{0x11AB0, 0x11AF8, 10},
+				{0x11AF9, 0x11BBF, 0},
+				{0x11BC0, 0x11BE0, 10},
+				{0x11BE1, 0x11BEF, 0},
+				{0x11BF0, 0x11BF9, 29},
+				{0x11BFA, 0x11BFF, 0},
+				{0x11C00, 0x11C08, 10},
+				{0x11C09, 0x11C09, 0},
+				{0x11C0A, 0x11C2E, 10},
+				{0x11C2F, 0x11C3F, 0},
+				{0x11C40, 0x11C40, 10},
+				{0x11C41, 0x11C4F, 0},
+				{0x11C50, 0x11C6C, 29},
+				{0x11C6D, 0x11C71, 0},
+				{0x11C72, 0x11C8F, 10},
+				{0x11C90, 0x11CFF, 0},
+				{0x11D00, 0x11D06, 10},
+				{0x11D07, 0x11D07, 0},
+				{0x11D08, 0x11D09, 10},
+				{0x11D0A, 0x11D0A, 0},
+				{0x11D0B, 0x11D30, 10},
+				{0x11D31, 0x11D45, 0},
+				{0x11D46, 0x11D46, 10},
+				{0x11D47, 0x11D4F, 0},
+				{0x11D50, 0x11D59, 29},
+				{0x11D5A, 0x11D5F, 0},
+				{0x11D60, 0x11D65, 10},
+				{0x11D66, 0x11D66, 0},
+				{0x11D67, 0x11D68, 10},
+				{0x11D69, 0x11D69, 0},
+				{0x11D6A, 0x11D89, 10},
+				{0x11D8A, 0x11D97, 0},
+				{0x11D98, 0x11D98, 10},
+				{0x11D99, 0x11D9F, 0},
+				{0x11DA0, 0x11DA9, 29},
+				{0x11DAA, 0x11DAF, 0},
+				{0x11DB0, 0x11DDB, 10},
+				{0x11DDC, 0x11DDF, 0},
+				{0x11DE0, 0x11DE9, 29},
+				{0x11DEA, 0x11EDF, 0},
+				{0x11EE0, 0x11EF2, 10},
+				{0x11EF3, 0x11F01, 0},
+				{0x11F02, 0x11F02, 10},
+				{0x11F03, 0x11F03, 0},
+				{0x11F04, 0x11F10, 10},
+				{0x11F11, 0x11F11, 0},
+				{0x11F12, 0x11F33, 10},
+				{0x11F34, 0x11F4F, 0},
+				{0x11F50, 0x11F59, 29},
+				{0x11F5A, 0x11FAF, 0},
+				{0x11FB0, 0x11FB0, 10},
+				{0x11FB1, 0x11FBF, 0},
+				{0x11FC0, 0x11FD4, 29},
+				{0x11FD5, 0x11FFF, 0},
+				{0x12000, 0x12399, 10},
+				{0x1239A, 0x123FF, 0},
+				{0x12400, 0x1246E, 29},
+				{0x1246F, 0x1247F, 0},
+				{0x12480, 0x12543, 10},
+				{0x12544, 0x12F8F, 0},
+				{0x12F90, 0x12FF0, 10},
+				{0x12FF1, 0x12FFF, 0},
+				{0x13000, 0x1342F, 10},
+				{0x13430, 0x13440, 0},
+				{0x13441, 0x13446, 10},
+				{0x13447, 0x1345F, 0},
+				{0x13460, 0x143FA, 10},
+				{0x143FB, 0x143FF, 0},
+				{0x14400, 0x14646, 10},
+				{0x14647, 0x160FF, 0},
+				{0x16100, 0x1611D, 10},
+				{0x1611E, 0x1612F, 0},
+				{0x16130, 0x16139, 29},
+				{0x1613A, 0x167FF, 0},
+				{0x16800, 0x16A38, 10},
+				{0x16A39, 0x16A3F, 0},
+				{0x16A40, 0x16A5E, 10},
+				{0x16A5F, 0x16A5F, 0},
+				{0x16A60, 0x16A69, 29},
+				{0x16A6A, 0x16A6F, 0},
+				{0x16A70, 0x16ABE, 10},
+				{0x16ABF, 0x16ABF, 0},
+				{0x16AC0, 0x16AC9, 29},
+				{0x16ACA, 0x16ACF, 0},
+				{0x16AD0, 0x16AED, 10},
+				{0x16AEE, 0x16AFF, 0},
+				{0x16B00, 0x16B2F, 10},
+				{0x16B30, 0x16B3F, 0},
+				{0x16B40, 0x16B43, 10},
+				{0x16B44, 0x16B4F, 0},
+				{0x16B50, 0x16B59, 29},
+				{0x16B5A, 0x16B5A, 0},
+				{0x16B5B, 0x16B61, 29},
+				{0x16B62, 0x16B62, 0},
+				{0x16B63, 0x16B77, 10},
+				{0x16B78, 0x16B7C, 0},
+				{0x16B7D, 0x16B8F, 10},
+				{0x16B90, 0x16D3F, 0},
+				{0x16D40, 0x16D6C, 10},
+				{0x16D6D, 0x16D6F, 0},
+				{0x16D70, 0x16D79, 29},
+				{0x16D7A, 0x16E3F, 0},
+				{0x16E40, 0x16E7F, 10},
+				{0x16E80, 0x16E96, 29},
+				{0x16E97, 0x16E9F, 0},
+				{0x16EA0, 0x16EB8, 10},
+				{0x16EB9, 0x16EBA, 0},
+				{0x16EBB, 0x16ED3, 10},
+				{0x16ED4, 0x16EFF, 0},
+				{0x16F00, 0x16F4A, 10},
+				{0x16F4B, 0x16F4F, 0},
+				{0x16F50, 0x16F50, 10},
+				{0x16F51, 0x16F92, 0},
+				{0x16F93, 0x16F9F, 10},
+				{0x16FA0, 0x16FDF, 0},
+				{0x16FE0, 0x16FE1, 10},
+				{0x16FE2, 0x16FE2, 0},
+				{0x16FE3, 0x16FE3, 10},
+				{0x16FE4, 0x16FF1, 0},
+				{0x16FF2, 0x16FF3, 10},
+				{0x16FF4, 0x16FF6, 29},
+				{0x16FF7, 0x16FFF, 0},
+				{0x17000, 0x18CD5, 10},
+				{0x18CD6, 0x18CFE, 0},
+				{0x18CFF, 0x18D1E, 10},
+				{0x18D1F, 0x18D7F, 0},
+				{0x18D80, 0x18DF2, 10},
+				{0x18DF3, 0x1AFEF, 0},
+				{0x1AFF0, 0x1AFF3, 10},
+				{0x1AFF4, 0x1AFF4, 0},
+				{0x1AFF5, 0x1AFFB, 10},
+				{0x1AFFC, 0x1AFFC, 0},
+				{0x1AFFD, 0x1AFFE, 10},
+				{0x1AFFF, 0x1AFFF, 0},
+				{0x1B000, 0x1B122, 10},
+				{0x1B123, 0x1B131, 0},
+				{0x1B132, 0x1B132, 10},
+				{0x1B133, 0x1B14F, 0},
+				{0x1B150, 0x1B152, 10},
+				{0x1B153, 0x1B154, 0},
+				{0x1B155, 0x1B155, 10},
+				{0x1B156, 0x1B163, 0},
+				{0x1B164, 0x1B167, 10},
+				{0x1B168, 0x1B16F, 0},
+				{0x1B170, 0x1B2FB, 10},
+				{0x1B2FC, 0x1BBFF, 0},
+				{0x1BC00, 0x1BC6A, 10},
+				{0x1BC6B, 0x1BC6F, 0},
+				{0x1BC70, 0x1BC7C, 10},
+				{0x1BC7D, 0x1BC7F, 0},
+				{0x1BC80, 0x1BC88, 10},
+				{0x1BC89, 0x1BC8F, 0},
+				{0x1BC90, 0x1BC99, 10},
+				{0x1BC9A, 0x1CCEF, 0},
+				{0x1CCF0, 0x1CCF9, 29},
+				{0x1CCFA, 0x1D2BF, 0},
+				{0x1D2C0, 0x1D2D3, 29},
+				{0x1D2D4, 0x1D2DF, 0},
+				{0x1D2E0, 0x1D2F3, 29},
+				{0x1D2F4, 0x1D35F, 0},
+				{0x1D360, 0x1D378, 29},
+				{0x1D379, 0x1D3FF, 0},
+				{0x1D400, 0x1D454, 10},
+				{0x1D455, 0x1D455, 0},
+				{0x1D456, 0x1D49C, 10},
+				{0x1D49D, 0x1D49D, 0},
+				{0x1D49E, 0x1D49F, 10},
+				{0x1D4A0, 0x1D4A1, 0},
+				{0x1D4A2, 0x1D4A2, 10},
+				{0x1D4A3, 0x1D4A4, 0},
+				{0x1D4A5, 0x1D4A6, 10},
+				{0x1D4A7, 0x1D4A8, 0},
+				{0x1D4A9, 0x1D4AC, 10},
+				{0x1D4AD, 0x1D4AD, 0},
+				{0x1D4AE, 0x1D4B9, 10},
+				{0x1D4BA, 0x1D4BA, 0},
+				{0x1D4BB, 0x1D4BB, 10},
+				{0x1D4BC, 0x1D4BC, 0},
+				{0x1D4BD, 0x1D4C3, 10},
+				{0x1D4C4, 0x1D4C4, 0},
+				{0x1D4C5, 0x1D505, 10},
+				{0x1D506, 0x1D506, 0},
+				{0x1D507, 0x1D50A, 10},
+				{0x1D50B, 0x1D50C, 0},
+				{0x1D50D, 0x1D514, 10},
+				{0x1D515, 0x1D515, 0},
+				{0x1D516, 0x1D51C, 10},
+				{0x1D51D, 0x1D51D, 0},
+				{0x1D51E, 0x1D539, 10},
+				{0x1D53A, 0x1D53A, 0},
+				{0x1D53B, 0x1D53E, 10},
+				{0x1D53F, 0x1D53F, 0},
+				{0x1D540, 0x1D544, 10},
+				{0x1D545, 0x1D545, 0},
+				{0x1D546, 0x1D546, 10},
+				{0x1D547, 0x1D549, 0},
+				{0x1D54A, 0x1D550, 10},
+				{0x1D551, 0x1D551, 0},
+				{0x1D552, 0x1D6A5, 10},
+				{0x1D6A6, 0x1D6A7, 0},
+				{0x1D6A8, 0x1D6C0, 10},
+				{0x1D6C1, 0x1D6C1, 0},
+				{0x1D6C2, 0x1D6DA, 10},
+				{0x1D6DB, 0x1D6DB, 0},
+				{0x1D6DC, 0x1D6FA, 10},
+				{0x1D6FB, 0x1D6FB, 0},
+				{0x1D6FC, 0x1D714, 10},
+				{0x1D715, 0x1D715, 0},
+				{0x1D716, 0x1D734, 10},
+				{0x1D735, 0x1D735, 0},
+				{0x1D736, 0x1D74E, 10},
+				{0x1D74F, 0x1D74F, 0},
+				{0x1D750, 0x1D76E, 10},
+				{0x1D76F, 0x1D76F, 0},
+				{0x1D770, 0x1D788, 10},
+				{0x1D789, 0x1D789, 0},
+				{0x1D78A, 0x1D7A8, 10},
+				{0x1D7A9, 0x1D7A9, 0},
+				{0x1D7AA, 0x1D7C2, 10},
+				{0x1D7C3, 0x1D7C3, 0},
+				{0x1D7C4, 0x1D7CB, 10},
+				{0x1D7CC, 0x1D7CD, 0},
+				{0x1D7CE, 0x1D7FF, 29},
+				{0x1D800, 0x1DEFF, 0},
+				{0x1DF00, 0x1DF1E, 10},
+				{0x1DF1F, 0x1DF24, 0},
+				{0x1DF25, 0x1DF2A, 10},
+				{0x1DF2B, 0x1E02F, 0},
+				{0x1E030, 0x1E06D, 10},
+				{0x1E06E, 0x1E0FF, 0},
+				{0x1E100, 0x1E12C, 10},
+				{0x1E12D, 0x1E136, 0},
+				{0x1E137, 0x1E13D, 10},
+				{0x1E13E, 0x1E13F, 0},
+				{0x1E140, 0x1E149, 29},
+				{0x1E14A, 0x1E14D, 0},
+				{0x1E14E, 0x1E14E, 10},
+				{0x1E14F, 0x1E28F, 0},
+				{0x1E290, 0x1E2AD, 10},
+				{0x1E2AE, 0x1E2BF, 0},
+				{0x1E2C0, 0x1E2EB, 10},
+				{0x1E2EC, 0x1E2EF, 0},
+				{0x1E2F0, 0x1E2F9, 29},
+				{0x1E2FA, 0x1E4CF, 0},
+				{0x1E4D0, 0x1E4EB, 10},
+				{0x1E4EC, 0x1E4EF, 0},
+				{0x1E4F0, 0x1E4F9, 29},
+				{0x1E4FA, 0x1E5CF, 0},
+				{0x1E5D0, 0x1E5ED, 10},
+				{0x1E5EE, 0x1E5EF, 0},
+				{0x1E5F0, 0x1E5F0, 10},
+				{0x1E5F1, 0x1E5FA, 29},
+				{0x1E5FB, 0x1E6BF, 0},
+				{0x1E6C0, 0x1E6DE, 10},
+				{0x1E6DF, 0x1E6DF, 0},
+				{0x1E6E0, 0x1E6E2, 10},
+				{0x1E6E3, 0x1E6E3, 0},
+				{0x1E6E4, 0x1E6E5, 10},
+				{0x1E6E6, 0x1E6E6, 0},
+				{0x1E6E7, 0x1E6ED, 10},
+				{0x1E6EE, 0x1E6EF, 0},
+				{0x1E6F0, 0x1E6F4, 10},
+				{0x1E6F5, 0x1E6FD, 0},
+				{0x1E6FE, 0x1E6FF, 10},
+				{0x1E700, 0x1E7DF, 0},
+				{0x1E7E0, 0x1E7E6, 10},
+				{0x1E7E7, 0x1E7E7, 0},
+				{0x1E7E8, 0x1E7EB, 10},
+				{0x1E7EC, 0x1E7EC, 0},
+				{0x1E7ED, 0x1E7EE, 10},
+				{0x1E7EF, 0x1E7EF, 0},
+				{0x1E7F0, 0x1E7FE, 10},
+				{0x1E7FF, 0x1E7FF, 0},
+				{0x1E800, 0x1E8C4, 10},
+				{0x1E8C5, 0x1E8C6, 0},
+				{0x1E8C7, 0x1E8CF, 29},
+				{0x1E8D0, 0x1E8FF, 0},
+				{0x1E900, 0x1E943, 10},
+				{0x1E944, 0x1E94A, 0},
+				{0x1E94B, 0x1E94B, 10},
+				{0x1E94C, 0x1E94F, 0},
+				{0x1E950, 0x1E959, 29},
+				{0x1E95A, 0x1EC70, 0},
+				{0x1EC71, 0x1ECAB, 29},
+				{0x1ECAC, 0x1ECAC, 0},
+				{0x1ECAD, 0x1ECAF, 29},
+				{0x1ECB0, 0x1ECB0, 0},
+				{0x1ECB1, 0x1ECB4, 29},
+				{0x1ECB5, 0x1ED00, 0},
+				{0x1ED01, 0x1ED2D, 29},
+				{0x1ED2E, 0x1ED2E, 0},
+				{0x1ED2F, 0x1ED3D, 29},
+				{0x1ED3E, 0x1EDFF, 0},
+				{0x1EE00, 0x1EE03, 10},
+				{0x1EE04, 0x1EE04, 0},
+				{0x1EE05, 0x1EE1F, 10},
+				{0x1EE20, 0x1EE20, 0},
+				{0x1EE21, 0x1EE22, 10},
+				{0x1EE23, 0x1EE23, 0},
+				{0x1EE24, 0x1EE24, 10},
+				{0x1EE25, 0x1EE26, 0},
+				{0x1EE27, 0x1EE27, 10},
+				{0x1EE28, 0x1EE28, 0},
+				{0x1EE29, 0x1EE32, 10},
+				{0x1EE33, 0x1EE33, 0},
+				{0x1EE34, 0x1EE37, 10},
+				{0x1EE38, 0x1EE38, 0},
+				{0x1EE39, 0x1EE39, 10},
+				{0x1EE3A, 0x1EE3A, 0},
+				{0x1EE3B, 0x1EE3B, 10},
+				{0x1EE3C, 0x1EE41, 0},
+				{0x1EE42, 0x1EE42, 10},
+				{0x1EE43, 0x1EE46, 0},
+				{0x1EE47, 0x1EE47, 10},
+				{0x1EE48, 0x1EE48, 0},
+				{0x1EE49, 0x1EE49, 10},
+				{0x1EE4A, 0x1EE4A, 0},
+				{0x1EE4B, 0x1EE4B, 10},
+				{0x1EE4C, 0x1EE4C, 0},
+				{0x1EE4D, 0x1EE4F, 10},
+				{0x1EE50, 0x1EE50, 0},
+				{0x1EE51, 0x1EE52, 10},
+				{0x1EE53, 0x1EE53, 0},
+				{0x1EE54, 0x1EE54, 10},
+				{0x1EE55, 0x1EE56, 0},
+				{0x1EE57, 0x1EE57, 10},
+				{0x1EE58, 0x1EE58, 0},
+				{0x1EE59, 0x1EE59, 10},
+				{0x1EE5A, 0x1EE5A, 0},
+				{0x1EE5B, 0x1EE5B, 10},
+				{0x1EE5C, 0x1EE5C, 0},
+				{0x1EE5D, 0x1EE5D, 10},
+				{0x1EE5E, 0x1EE5E, 0},
+				{0x1EE5F, 0x1EE5F, 10},
+				{0x1EE60, 0x1EE60, 0},
+				{0x1EE61, 0x1EE62, 10},
+				{0x1EE63, 0x1EE63, 0},
+				{0x1EE64, 0x1EE64, 10},
+				{0x1EE65, 0x1EE66, 0},
+				{0x1EE67, 0x1EE6A, 10},
+				{0x1EE6B, 0x1EE6B, 0},
+				{0x1EE6C, 0x1EE72, 10},
+				{0x1EE73, 0x1EE73, 0},
+				{0x1EE74, 0x1EE77, 10},
+				{0x1EE78, 0x1EE78, 0},
+				{0x1EE79, 0x1EE7C, 10},
+				{0x1EE7D, 0x1EE7D, 0},
+				{0x1EE7E, 0x1EE7E, 10},
+				{0x1EE7F, 0x1EE7F, 0},
+				{0x1EE80, 0x1EE89, 10},
+				{0x1EE8A, 0x1EE8A, 0},
+				{0x1EE8B, 0x1EE9B, 10},
+				{0x1EE9C, 0x1EEA0, 0},
+				{0x1EEA1, 0x1EEA3, 10},
+				{0x1EEA4, 0x1EEA4, 0},
+				{0x1EEA5, 0x1EEA9, 10},
+				{0x1EEAA, 0x1EEAA, 0},
+				{0x1EEAB, 0x1EEBB, 10},
+				{0x1EEBC, 0x1F0FF, 0},
+				{0x1F100, 0x1F10C, 29},
+				{0x1F10D, 0x1FBEF, 0},
+				{0x1FBF0, 0x1FBF9, 29},
+				{0x1FBFA, 0x1FFFF, 0},
+				{0x20000, 0x2A6DF, 10},
+				{0x2A6E0, 0x2A6FF, 0},
+				{0x2A700, 0x2B81D, 10},
+				{0x2B81E, 0x2B81F, 0},
+				{0x2B820, 0x2CEAD, 10},
+				{0x2CEAE, 0x2CEAF, 0},
+				{0x2CEB0, 0x2EBE0, 10},
+				{0x2EBE1, 0x2EBEF, 0},
+				{0x2EBF0, 0x2EE5D, 10},
+				{0x2EE5E, 0x2F7FF, 0},
+				{0x2F800, 0x2FA1D, 10},
+				{0x2FA1E, 0x2FFFF, 0},
+				{0x30000, 0x3134A, 10},
+				{0x3134B, 0x3134F, 0},
+				{0x31350, 0x33479, 10},
+				{0x3347A, 0x10FFFF, 0},
+			},
+			[][]int{
+				{-1, -1, 1, 2, 2, 2, 3, 4, 5, 6, 7, 7, 7, 8, 7, 7, 7, 7, 9, 7, 10, 7, 11, 12, 13, 7, 7, 9, 7, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, 14, -1, -1, 15, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, 16, -1, 5, -1, -1, -1, 17, -1, -1, -1, -1, -1, -1, -1, -1, -1, 17, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, 2, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 18, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 19, 7, 7, 7, 7, 7, 7, 7, -1, 7, 19, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 20, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 18, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 21, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 22, 7, 7, 7, 7, 7, 7},
+				{14, 14, 14, 14, 23, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14},
+				{15, -1, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 24, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, 25, -1, -1, 26, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 27, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 28, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 28, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 29, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 30, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{14, 14, 14, 14, 23, 14, 14, 31, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 24, -1, -1, -1, 17, -1, -1, -1, -1, -1, -1, -1, -1, -1, 17, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 26, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 26, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 32, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 33, 7, 7, 7, 7, 7, 7, 7, -1, 7, 33, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 22, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 22, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 34, 7, 7, 7, 7, 7, 7, 7, 7, -1, 34, 7, 7, 7, 7, 7, 7, 7, 7},
+				{-1, -1, -1, -1, -1, -1, -1, -1, 7, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7, 32, -1, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+			},
+		),
+		rules: []lexRule{
+			{1, lexNone, 0},
+			{12, lexNone, 0},
+			{13, lexNone, 0},
+			{2, lexNone, 0},
+			{3, lexNone, 0},
+			{4, lexNone, 0},
+			{5, lexNone, 0},
+			{6, lexNone, 0},
+			{7, lexPush, 2},
+			{8, lexSwitch, 1},
+		},
+	},
+	{
+		name: "raw",
+		whitespace: newLexDFA(
+			[]int{-1, 0},
+			[]lexRange{
+				{' ', ' ', 0},
+			},
+			[][]int{
+				{1},
+				{-1},
+			},
+		),
+		tokens: newLexDFA(
+			[]int{-1, 2, 1, 0},
+			[]lexRange{
+				{0x0, 0x1F, 0},
+				{'!', '(', 0},
+				{')', ')', 1},
+				{'*', '_', 0},
+				{'`', '`', 2},
+				{'a', 0x10FFFF, 0},
+			},
+			[][]int{
+				{1, 2, 3},
+				{1, -1, -1},
+				{-1, -1, -1},
+				{-1, -1, -1},
+			},
+		),
+		rules: []lexRule{
+			{8, lexSwitch, 0},
+			{11, lexPop, 0},
+			{10, lexNone, 0},
+		},
+	},
+	{
+		name:       "string",
+		whitespace: nil,
+		tokens: newLexDFA(
+			[]int{-1, 2, 0, -1, 1},
+			[]lexRange{
+				{0x0, 0x9, 0},
+				{0xB, '!', 0},
+				{'"', '"', 1},
+				{'#', '[', 0},
+				{'\\', '\\', 2},
+				{']', 0x10FFFF, 0},
+			},
+			[][]int{
+				{1, 2, 3},
+				{1, -1, -1},
+				{-1, -1, -1},
+				{4, 4, 4},
+				{-1, -1, -1},
+			},
+		),
+		rules: []lexRule{
+			{7, lexPop, 0},
+			{9, lexNone, 0},
+			{10, lexNone, 0},
+		},
+	},
+}
+
+// Lexer is a table-driven lexer that generated by simplexer-gen.
+type Lexer struct {
+	reader  io.Reader
+	chunk   []byte
+	input   string
+	base    int
+	eof     bool
+	readErr error
+	offset  int
+	pos     Position
+	stack   []int
+}
+
+/*
+Make new Lexer.
+
+Lexer reads from reader when it needs more input, like the Lexer of simplexer.
+If reader returned an error, tokens in the input that read before the error are returned first, and then ReaderError is returned.
+*/
+func NewLexer(reader io.Reader) *Lexer {
+	return &Lexer{
+		reader: reader,
+		stack:  []int{0},
+	}
+}
+
+// Make new Lexer that reads s.
+func NewLexerString(s string) *Lexer {
+	return &Lexer{
+		input: s,
+		eof:   true,
+		stack: []int{0},
+	}
+}
+
+// rest returns the buffered input after the cursor.
+func (l *Lexer) rest() string {
+	return l.input[l.offset-l.base:]
+}
+
+// fill reads from reader until the buffer after the cursor has size bytes or reached to EOF.
+func (l *Lexer) fill(size int) {
+	chunk := l.chunk[:0]
+	emptyReads := 0
+	for !l.eof && len(l.rest())+len(chunk) < size {
+		chunk = slices.Grow(chunk, 2048)
+		n, err := l.reader.Read(chunk[len(chunk):cap(chunk)])
+		chunk = chunk[:len(chunk)+n]
+
+		switch {
+		case err == io.EOF:
+			l.eof = true
+		case err != nil:
+			l.eof = true
+			l.readErr = err
+		case n == 0:
+			if emptyReads++; emptyReads >= 100 {
+				l.eof = true
+				l.readErr = io.ErrNoProgress
+			}
+		}
+	}
+	l.input += string(chunk)
+
+	if cap(chunk) <= 4096 {
+		l.chunk = chunk[:0]
+	}
+}
+
+// advance moves the cursor after s, and drops the buffer before the current line.
+func (l *Lexer) advance(s string) {
+	l.pos = l.pos.shift(s)
+	l.offset += len(s)
+
+	if idx := strings.LastIndexByte(s, '\n'); idx >= 0 {
+		head := l.offset - len(s) + idx + 1
+		l.input = l.input[head-l.base:]
+		l.base = head
+	}
+}
+
+// State returns the name of the current state.
+func (l *Lexer) State() string {
+	return lexStates[l.stack[len(l.stack)-1]].name
+}
+
+func (l *Lexer) skipWhitespace() {
+	whitespace := lexStates[l.stack[len(l.stack)-1]].whitespace
+	if whitespace == nil {
+		return
+	}
+
+	for {
+		l.fill(1024)
+
+		_, n := whitespace.match(l.rest(), false)
+		if n == 0 {
+			return
+		}
+		l.advance(l.rest()[:n])
+	}
+}
+
+/*
+matchToken matches the rules of the current state, and reads more input if the token reached to the end of the buffer.
+
+The buffer might end in the middle of a rune too, so the token is matched again if a partial rune follows it.
+*/
+func (l *Lexer) matchToken() (rule, length int) {
+	state := &lexStates[l.stack[len(l.stack)-1]]
+
+	for {
+		rest := l.rest()
+		rule, length = state.tokens.match(rest, lexLongest)
+		if l.eof {
+			return rule, length
+		}
+		if rule < 0 && (rest == "" || utf8.FullRuneInString(rest)) {
+			return rule, length
+		}
+		if rule >= 0 && length < len(rest) && utf8.FullRuneInString(rest[length:]) {
+			return rule, length
+		}
+
+		l.fill(len(rest) * 2)
+	}
+}
+
+func (l *Lexer) makeError() UnknownTokenError {
+	state := &lexStates[l.stack[len(l.stack)-1]]
+	rest := l.rest()
+
+	literal := rest
+	for shift := range rest {
+		if state.whitespace != nil {
+			if _, n := state.whitespace.match(rest[shift:], false); n > 0 {
+				literal = rest[:shift]
+				break
+			}
+		}
+		if rule, _ := state.tokens.match(rest[shift:], false); rule >= 0 {
+			literal = rest[:shift]
+			break
+		}
+	}
+
+	return UnknownTokenError{
+		Literal:  literal,
+		Position: l.pos,
+		Line:     l.GetLastLine(),
+	}
+}
+
+/*
+GetLastLine returns the line of the cursor, that is the line of the last scanned token or the next line of it.
+
+The line might be a part of the line if the rest of the line is not read yet.
+*/
+func (l *Lexer) GetLastLine() string {
+	line := l.input
+	if idx := strings.IndexByte(line, '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+	return line
+}
+
+// next finds a token at the cursor without consuming it.
+func (l *Lexer) next() (*Token, *lexRule, error) {
+	l.skipWhitespace()
+
+	rest := l.rest()
+	if rest == "" {
+		if l.readErr != nil {
+			return nil, nil, ReaderError{Err: l.readErr, Position: l.pos}
+		}
+		return nil, nil, nil
+	}
+
+	i, n := l.matchToken()
+	if i < 0 {
+		return nil, nil, l.makeError()
+	}
+	rest = l.rest()
+
+	rule := &lexStates[l.stack[len(l.stack)-1]].rules[i]
+	if rule.action == lexPop && len(l.stack) <= 1 {
+		return nil, nil, StateStackError{Position: l.pos}
+	}
+
+	return &Token{
+		ID:        rule.id,
+		Literal:   rest[:n],
+		Position:  l.pos,
+		End:       l.pos.shift(rest[:n]),
+		Offset:    l.offset,
+		EndOffset: l.offset + n,
+	}, rule, nil
+}
+
+/*
+Peek the first token in the input without consuming it.
+
+Returns nil as *Token at the end of input.
+Returns UnknownTokenError if no rule matched, or StateStackError if the token can not pop the state.
+*/
+func (l *Lexer) Peek() (*Token, error) {
+	t, _, err := l.next()
+	return t, err
+}
+
+/*
+Scan will get the first token in the input and consume it.
+
+If the rule of the token changes state, Scan changes the state of Lexer.
+Please read document of Peek about errors.
+*/
+func (l *Lexer) Scan() (*Token, error) {
+	t, rule, err := l.next()
+	if t == nil || err != nil {
+		return nil, err
+	}
+
+	switch rule.action {
+	case lexPush:
+		l.stack = append(l.stack, rule.state)
+	case lexPop:
+		l.stack = l.stack[:len(l.stack)-1]
+	case lexSwitch:
+		l.stack[len(l.stack)-1] = rule.state
+	}
+
+	l.advance(t.Literal)
+
+	return t, nil
+}
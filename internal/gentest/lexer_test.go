@@ -0,0 +1,304 @@
+package gentest_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/macrat/simplexer"
+	"github.com/macrat/simplexer/internal/gen"
+	"github.com/macrat/simplexer/internal/gentest"
+	"github.com/macrat/simplexer/internal/gentest/longest"
+)
+
+func loadSpec(t *testing.T, path string) *simplexer.Spec {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read spec: %s", err)
+	}
+
+	spec, err := simplexer.ParseSpec(data)
+	if err != nil {
+		t.Fatalf("failed to parse spec: %s", err)
+	}
+	return spec
+}
+
+// brokenReader returns a reader that returns an error after input.
+func brokenReader(input string) io.Reader {
+	return io.MultiReader(strings.NewReader(input), iotest.ErrReader(errors.New("broken")))
+}
+
+// interpreted runs the interpreted Lexer, and returns the output as text.
+func interpreted(spec *simplexer.Spec, reader io.Reader) (string, error) {
+	lexer, err := spec.NewLexer(reader)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			fmt.Fprintf(&buf, "error %s\n", err)
+			break
+		}
+		if token == nil {
+			break
+		}
+		fmt.Fprintf(&buf, "%d %#v %d:%d-%d:%d %d-%d %s\n",
+			token.Type.GetID(), token.Literal,
+			token.Position.Line, token.Position.Column, token.End.Line, token.End.Column,
+			token.Offset, token.EndOffset, lexer.State())
+	}
+	return buf.String(), nil
+}
+
+// generated runs the generated Lexer, and returns the output as text in the same format as interpreted.
+func generated(lexer *gentest.Lexer) string {
+	var buf strings.Builder
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			fmt.Fprintf(&buf, "error %s\n", err)
+			break
+		}
+		if token == nil {
+			break
+		}
+		fmt.Fprintf(&buf, "%d %#v %d:%d-%d:%d %d-%d %s\n",
+			int(token.ID), token.Literal,
+			token.Position.Line, token.Position.Column, token.End.Line, token.End.Column,
+			token.Offset, token.EndOffset, lexer.State())
+	}
+	return buf.String()
+}
+
+// generatedLongest is generated for the Lexer that generated from longest/spec.json.
+func generatedLongest(lexer *longest.Lexer) string {
+	var buf strings.Builder
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			fmt.Fprintf(&buf, "error %s\n", err)
+			break
+		}
+		if token == nil {
+			break
+		}
+		fmt.Fprintf(&buf, "%d %#v %d:%d-%d:%d %d-%d %s\n",
+			int(token.ID), token.Literal,
+			token.Position.Line, token.Position.Column, token.End.Line, token.End.Column,
+			token.Offset, token.EndOffset, lexer.State())
+	}
+	return buf.String()
+}
+
+var corpus = []string{
+	"",
+	"if x == 1 {\n\treturn \"hello\\n world\"\n} else { y = 2.5e-3 }",
+	"func main() { // comment\n\tfmt(\"a\\\"b\") }",
+	"SELECT name FROM users; select * from t",
+	"iffy elsewhere funcs",
+	"日本語 = café + ñ_1",
+	"a <= b << c < d",
+	"x = `raw text here` y",
+	"x = `raw )",
+	"1.5 1. .5 1e 1e+ 1e+5",
+	"\"unterminated\nstring\"",
+	"a @ b",
+	"a @#$ b",
+	"\xff\xfe x",
+	"\"bad \xff utf8\"",
+	"x // trailing comment",
+	"/* a */ x /* b */",
+	"/* multi\nline */ /* unterminated",
+	"a .. b . c ... d",
+}
+
+var fragments = []string{
+	"if", "else", "func", "select", "FrOm", "x", "y_1", "é", "日本", "0", "42", "3.14", "1e9", "2E-3",
+	"==", "=", "+", "-", "*", "/", "//", "(", ")", "{", "}", ";", "<", "<=", "<<",
+	"\"", "\\", "\\n", "`", " ", "  ", "\t", "\n", "\r\n", "@", "#", "\xff", ".", "..", "/*", "*/",
+}
+
+func TestGenerated_corpus(t *testing.T) {
+	spec := loadSpec(t, "spec.json")
+
+	for _, input := range corpus {
+		except, err := interpreted(spec, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("failed to make lexer: %s", err)
+		}
+
+		if got := generated(gentest.NewLexer(strings.NewReader(input))); got != except {
+			t.Errorf("%#v: excepted output\n%s\nbut got\n%s", input, except, got)
+		}
+		if got := generated(gentest.NewLexerString(input)); got != except {
+			t.Errorf("%#v: excepted output of NewLexerString\n%s\nbut got\n%s", input, except, got)
+		}
+	}
+}
+
+func TestGenerated_readerError(t *testing.T) {
+	spec := loadSpec(t, "spec.json")
+
+	for _, input := range corpus {
+		except, err := interpreted(spec, brokenReader(input))
+		if err != nil {
+			t.Fatalf("failed to make lexer: %s", err)
+		}
+
+		if got := generated(gentest.NewLexer(brokenReader(input))); got != except {
+			t.Errorf("%#v: excepted output\n%s\nbut got\n%s", input, except, got)
+		}
+	}
+}
+
+func TestGenerated_random(t *testing.T) {
+	spec := loadSpec(t, "spec.json")
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 3000; i++ {
+		var input strings.Builder
+		for n := rnd.Intn(20); n > 0; n-- {
+			input.WriteString(fragments[rnd.Intn(len(fragments))])
+		}
+
+		except, err := interpreted(spec, strings.NewReader(input.String()))
+		if err != nil {
+			t.Fatalf("failed to make lexer: %s", err)
+		}
+
+		if got := generated(gentest.NewLexer(strings.NewReader(input.String()))); got != except {
+			t.Fatalf("%#v: excepted output\n%s\nbut got\n%s", input.String(), except, got)
+		}
+	}
+}
+
+var longestCorpus = []string{
+	"",
+	"if iffy x == 1.5 ~ a ~~b",
+	"a <-- b <- c <= d < e",
+	"1.5.5 .5 1. 12",
+	"x\n~y \t~ z ~",
+	"a @ b",
+}
+
+var longestFragments = []string{
+	"if", "i", "f", "x", "abc", "0", "12", "1.5", ".", ".5",
+	"=", "==", "~", "<", "<=", "<-", "<--", "-", " ", "\t", "\n", "@", "é",
+}
+
+func TestGeneratedLongest_corpus(t *testing.T) {
+	spec := loadSpec(t, "longest/spec.json")
+
+	for _, input := range longestCorpus {
+		for _, broken := range []bool{false, true} {
+			reader := func() io.Reader {
+				if broken {
+					return brokenReader(input)
+				}
+				return strings.NewReader(input)
+			}
+
+			except, err := interpreted(spec, reader())
+			if err != nil {
+				t.Fatalf("failed to make lexer: %s", err)
+			}
+
+			if got := generatedLongest(longest.NewLexer(reader())); got != except {
+				t.Errorf("%#v/%v: excepted output\n%s\nbut got\n%s", input, broken, except, got)
+			}
+		}
+	}
+}
+
+func TestGeneratedLongest_random(t *testing.T) {
+	spec := loadSpec(t, "longest/spec.json")
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 3000; i++ {
+		var input strings.Builder
+		for n := rnd.Intn(20); n > 0; n-- {
+			input.WriteString(longestFragments[rnd.Intn(len(longestFragments))])
+		}
+
+		except, err := interpreted(spec, strings.NewReader(input.String()))
+		if err != nil {
+			t.Fatalf("failed to make lexer: %s", err)
+		}
+
+		if got := generatedLongest(longest.NewLexer(strings.NewReader(input.String()))); got != except {
+			t.Fatalf("%#v: excepted output\n%s\nbut got\n%s", input.String(), except, got)
+		}
+	}
+}
+
+func TestGenerated_peek(t *testing.T) {
+	lexer := gentest.NewLexerString("x \"a\"")
+
+	for _, except := range []string{`IDENT("x")`, `QUOTE("\"")`, `TEXT("a")`, `QUOTE("\"")`} {
+		peeked, err := lexer.Peek()
+		if err != nil {
+			t.Fatalf("unexcepted error: %s", err)
+		}
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("unexcepted error: %s", err)
+		}
+
+		if peeked.String() != except || token.String() != except {
+			t.Errorf("excepted %s but got %s and %s", except, peeked, token)
+		}
+	}
+
+	if token, err := lexer.Scan(); token != nil || err != nil {
+		t.Errorf("excepted end of input but got %v, %v", token, err)
+	}
+}
+
+func TestGenerated_GetLastLine(t *testing.T) {
+	lexer := gentest.NewLexer(strings.NewReader("a b\nc d"))
+
+	for _, except := range []string{"a b", "a b", "c d", "c d"} {
+		if _, err := lexer.Scan(); err != nil {
+			t.Fatalf("unexcepted error: %s", err)
+		}
+		if line := lexer.GetLastLine(); line != except {
+			t.Errorf("excepted %#v but got %#v", except, line)
+		}
+	}
+}
+
+func TestGenerated_upToDate(t *testing.T) {
+	for _, pkg := range []struct {
+		Name string
+		Dir  string
+	}{
+		{"gentest", "."},
+		{"longest", "longest"},
+	} {
+		src, err := gen.Generate(loadSpec(t, pkg.Dir+"/spec.json"), pkg.Name)
+		if err != nil {
+			t.Fatalf("failed to generate: %s", err)
+		}
+
+		current, err := os.ReadFile(pkg.Dir + "/lexer_gen.go")
+		if err != nil {
+			t.Fatalf("failed to read lexer_gen.go: %s", err)
+		}
+
+		if !bytes.Equal(src, current) {
+			t.Errorf("%s/lexer_gen.go is out of date. please run go generate", pkg.Dir)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package longest is a lexer that generated by simplexer-gen from spec.json with LongestMatch, for testing the generator.
+package longest
+
+//go:generate go run ../../../cmd/simplexer-gen -spec spec.json -package longest -o lexer_gen.go
@@ -0,0 +1,51 @@
+package simplexer
+
+import (
+	"iter"
+)
+
+/*
+All returns an iterator over tokens in the Lexer.
+
+The iterator yields each token with nil error, and stops at the end of input.
+If Scan returned an error, the iterator yields nil token with the error, and stops.
+
+Tokens are scanned by Lexer.Scan during the loop.
+If the loop was broken, the yielded tokens are already consumed,
+and the next Scan or All continues from the next token.
+*/
+func (l *Lexer) All() iter.Seq2[*Token, error] {
+	return func(yield func(*Token, error) bool) {
+		for {
+			t, err := l.Scan()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if t == nil {
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Tokens scans all tokens and returns them.
+
+If Scan returned an error, Tokens returns tokens that scanned before the error, and the error.
+*/
+func (l *Lexer) Tokens() ([]*Token, error) {
+	var tokens []*Token
+
+	for t, err := range l.All() {
+		if err != nil {
+			return tokens, err
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,105 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleLexer_All() {
+	lexer := simplexer.NewLexer(strings.NewReader("hello_world = \"hello world\"\nnumber = 1"))
+
+	for token, err := range lexer.All() {
+		if err != nil {
+			panic(err.Error())
+		}
+
+		fmt.Printf("%s: %s\n", token.Type, token.Literal)
+	}
+
+	// Output:
+	// IDENT: hello_world
+	// OTHER: =
+	// STRING: "hello world"
+	// IDENT: number
+	// OTHER: =
+	// NUMBER: 1
+}
+
+func TestLexer_All_break(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a b c d"))
+
+	var literals []string
+	for token, err := range lexer.All() {
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		literals = append(literals, token.Literal)
+		if token.Literal == "b" {
+			break
+		}
+	}
+
+	for token, err := range lexer.All() {
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		literals = append(literals, token.Literal)
+	}
+
+	if s := strings.Join(literals, ","); s != "a,b,c,d" {
+		t.Errorf("excepted \"a,b,c,d\" but got %#v", s)
+	}
+}
+
+func TestLexer_All_error(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 2 ! 3"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+	}
+
+	count := 0
+	var lastErr error
+	for token, err := range lexer.All() {
+		count++
+		if err != nil {
+			if token != nil {
+				t.Errorf("excepted nil token with error but got %s", token)
+			}
+			lastErr = err
+		}
+	}
+
+	if count != 3 {
+		t.Errorf("excepted 3 iterations but got %d", count)
+	}
+	if _, ok := lastErr.(simplexer.UnknownTokenError); !ok {
+		t.Errorf("excepted UnknownTokenError but got %#v", lastErr)
+	}
+}
+
+func TestLexer_Tokens(t *testing.T) {
+	tokens, err := simplexer.NewLexer(strings.NewReader("a = 1")).Tokens()
+	if err != nil {
+		t.Fatalf("failed scan: %s", err.Error())
+	}
+
+	if len(tokens) != 3 || tokens[0].Literal != "a" || tokens[1].Literal != "=" || tokens[2].Literal != "1" {
+		t.Errorf("unexcepted tokens: %v", tokens)
+	}
+
+	lexer := simplexer.NewLexer(strings.NewReader("1 2 ! 3"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+	}
+
+	tokens, err = lexer.Tokens()
+	if err == nil {
+		t.Errorf("excepted error but got nil")
+	}
+	if len(tokens) != 2 {
+		t.Errorf("excepted 2 tokens before error but got %v", tokens)
+	}
+}
@@ -0,0 +1,137 @@
+package simplexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultIdentifier is the default rule of identifier for KeywordTokenType.
+var DefaultIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+
+/*
+KeywordTokenType is a TokenType for reserved words.
+
+KeywordTokenType finds an identifier first by Identifier, and then looks up it from keywords.
+So it matches only a whole identifier, never matches a prefix of longer identifier like "if" of "iffy".
+
+Identifier is a regular expression of identifier. It have to starts with "^".
+The default is DefaultIdentifier.
+
+Each keyword has its own TokenID, and Token.Type of found token is a Keyword.
+*/
+type KeywordTokenType struct {
+	Identifier *regexp.Regexp
+	ignoreCase bool
+	keywords   map[string]*Keyword
+}
+
+/*
+Keyword is a TokenType of a word in KeywordTokenType.
+
+It is used as Token.Type of tokens that found by KeywordTokenType.
+*/
+type Keyword struct {
+	ID     TokenID
+	Word   string
+	parent *KeywordTokenType
+}
+
+/*
+Make new KeywordTokenType.
+
+keywords is a map of keyword to TokenID.
+
+If ignoreCase is true, keywords will match case-insensitively, like SQL or Pascal.
+*/
+func NewKeywordTokenType(keywords map[string]TokenID, ignoreCase bool) *KeywordTokenType {
+	ktt := &KeywordTokenType{
+		Identifier: DefaultIdentifier,
+		ignoreCase: ignoreCase,
+		keywords:   make(map[string]*Keyword, len(keywords)),
+	}
+
+	for word, id := range keywords {
+		ktt.keywords[ktt.normalize(word)] = &Keyword{
+			ID:     id,
+			Word:   word,
+			parent: ktt,
+		}
+	}
+
+	return ktt
+}
+
+func (ktt *KeywordTokenType) normalize(s string) string {
+	if ktt.ignoreCase {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// Get readable string of keywords.
+func (ktt *KeywordTokenType) String() string {
+	return "KEYWORD"
+}
+
+/*
+GetID returns OTHER.
+
+The TokenID of KeywordTokenType has no meaning, because each keyword has own TokenID.
+*/
+func (ktt *KeywordTokenType) GetID() TokenID {
+	return OTHER
+}
+
+// Lookup returns Keyword of the word, or nil if the word is not a keyword.
+func (ktt *KeywordTokenType) Lookup(word string) *Keyword {
+	return ktt.keywords[ktt.normalize(word)]
+}
+
+// findKeyword returns the identifier at the head of s and its Keyword.
+func (ktt *KeywordTokenType) findKeyword(s string) (string, *Keyword) {
+	loc := ktt.Identifier.FindStringIndex(s)
+	if loc == nil || loc[0] != 0 || loc[1] == 0 {
+		return "", nil
+	}
+
+	word := s[:loc[1]]
+	return word, ktt.Lookup(word)
+}
+
+// FindToken returns new Token if s starts with a keyword.
+func (ktt *KeywordTokenType) FindToken(s string, p Position) *Token {
+	word, kw := ktt.findKeyword(s)
+	if kw == nil {
+		return nil
+	}
+
+	return &Token{
+		Type:     kw,
+		Literal:  word,
+		Position: p,
+	}
+}
+
+// Get readable string of TokenID.
+func (kw *Keyword) String() string {
+	return kw.ID.String()
+}
+
+// GetID returns id of this keyword.
+func (kw *Keyword) GetID() TokenID {
+	return kw.ID
+}
+
+// FindToken returns new Token if s starts with this keyword.
+func (kw *Keyword) FindToken(s string, p Position) *Token {
+	word, found := kw.parent.findKeyword(s)
+	if found != kw {
+		return nil
+	}
+
+	return &Token{
+		Type:     kw,
+		Literal:  word,
+		Position: p,
+	}
+}
@@ -0,0 +1,130 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewKeywordTokenType() {
+	const (
+		IF simplexer.TokenID = iota
+		ELSE
+	)
+
+	lexer := simplexer.NewLexer(strings.NewReader("if iffy else elsewhere"))
+
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewKeywordTokenType(map[string]simplexer.TokenID{
+			"if":   IF,
+			"else": ELSE,
+		}, false),
+	}, lexer.TokenTypes...)
+
+	for {
+		token, _ := lexer.Scan()
+		if token == nil {
+			break
+		}
+
+		switch token.Type.GetID() {
+		case IF:
+			fmt.Printf("%s is if\n", token.Literal)
+		case ELSE:
+			fmt.Printf("%s is else\n", token.Literal)
+		default:
+			fmt.Printf("%s is identifier\n", token.Literal)
+		}
+	}
+
+	// Output:
+	// if is if
+	// iffy is identifier
+	// else is else
+	// elsewhere is identifier
+}
+
+func TestKeywordTokenType(t *testing.T) {
+	const (
+		SELECT simplexer.TokenID = iota
+		FROM
+	)
+
+	tt := simplexer.NewKeywordTokenType(map[string]simplexer.TokenID{
+		"select": SELECT,
+		"from":   FROM,
+	}, true)
+
+	tests := []struct {
+		Input   string
+		ID      simplexer.TokenID
+		Literal string
+	}{
+		{"select * from x", SELECT, "select"},
+		{"SELECT * FROM x", SELECT, "SELECT"},
+		{"From x", FROM, "From"},
+		{"selected", -100, ""},
+		{"x from", -100, ""},
+		{"", -100, ""},
+	}
+
+	pos := simplexer.Position{Line: 1, Column: 2}
+
+	for _, tc := range tests {
+		tok := tt.FindToken(tc.Input, pos)
+
+		if tc.ID == -100 {
+			if tok != nil {
+				t.Errorf("%#v: excepted nil but got %s", tc.Input, tok)
+			}
+			continue
+		}
+
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+		if tok.Type.GetID() != tc.ID {
+			t.Errorf("%#v: excepted type %s but got %s", tc.Input, tc.ID, tok.Type.GetID())
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Position != pos {
+			t.Errorf("%#v: excepted position %s but got %s", tc.Input, pos, tok.Position)
+		}
+		if kw, ok := tok.Type.(*simplexer.Keyword); !ok || kw.Word != strings.ToLower(tc.Literal) {
+			t.Errorf("%#v: excepted Keyword of %#v but got %#v", tc.Input, strings.ToLower(tc.Literal), tok.Type)
+		}
+	}
+
+	if kw := tt.Lookup("FROM"); kw == nil || kw.ID != FROM {
+		t.Errorf("excepted FROM but got %#v", kw)
+	}
+	if kw := tt.Lookup("select"); kw == nil || kw.FindToken("SELECT x", pos) == nil || kw.FindToken("from", pos) != nil {
+		t.Errorf("Keyword.FindToken works wrong")
+	}
+}
+
+func TestKeywordTokenType_Identifier(t *testing.T) {
+	tt := simplexer.NewKeywordTokenType(map[string]simplexer.TokenID{
+		"begin": 0,
+		"end":   1,
+	}, false)
+	tt.Identifier = regexp.MustCompile(`^[a-z][a-z0-9-]*`)
+
+	if tok := tt.FindToken("end-point", simplexer.Position{}); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+
+	if tok := tt.FindToken("end+1", simplexer.Position{}); tok == nil || tok.Literal != "end" {
+		t.Errorf("excepted \"end\" but got %s", tok)
+	}
+
+	if tok := tt.FindToken("x end", simplexer.Position{}); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+}
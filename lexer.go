@@ -2,7 +2,11 @@ package simplexer
 
 import (
 	"io"
+	"math"
+	"slices"
 	"strings"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // Defined default values for properties of Lexer as a package value.
@@ -15,6 +19,23 @@ var (
 		NewRegexpTokenType(STRING, `\"([^"]*)\"`),
 		NewRegexpTokenType(OTHER, `.`),
 	}
+
+	ErrorTokenType = NewPatternTokenType(ERROR, nil)
+
+	// EOFTokenType is a TokenType of the token at the end of input that keeps trivia in Lossless mode.
+	EOFTokenType = NewPatternTokenType(EOF, nil)
+)
+
+// MatchStrategy is a strategy for selecting a token from matched TokenTypes.
+type MatchStrategy int
+
+const (
+	// FirstMatch selects the first matched TokenType in Lexer.TokenTypes.
+	FirstMatch MatchStrategy = iota
+
+	// LongestMatch selects the TokenType that matched the longest literal.
+	// If some TokenTypes matched the same length literal, selects the first one of them.
+	LongestMatch
 )
 
 /*
@@ -23,6 +44,7 @@ The lexical analyzer.
 Whitespace is a TokenType for skipping characters like whitespaces.
 The default value is simplexer.DefaultWhitespace.
 Won't skip any characters if Whitespace is nil.
+Comments of LineCommentTokenType and BlockCommentTokenType with Trivia are skipped like whitespaces too.
 
 TokenTypes is an array of TokenType.
 Lexer will sequential check TokenTypes, and return first matched token.
@@ -30,14 +52,77 @@ Default is simplexer.DefaultTokenTypes.
 
 Please be careful, Lexer will never use it even if append TokenType after OTHER.
 Because OTHER will accept any single character.
+
+Strategy is a MatchStrategy for selecting a token when some TokenTypes matched.
+The default is FirstMatch.
+If set LongestMatch, Lexer will check all TokenTypes and use the longest token.
+In this case, TokenType after OTHER will be used if it matched more than a character.
+
+Columns is a set of ColumnUnits to count in Position, in addition to byte column.
+For example, set RuneColumns|DisplayColumns for error messages that shown in terminal.
+TabWidth is the width of tab stops for DisplayColumns. DefaultTabWidth is used if it is 0.
+
+Recovery is a RecoveryMode for unknown tokens. The default is NoRecovery.
+MaxErrors is the maximum number of errors for recovery. Lexer won't stop if it is 0.
+
+File is a File in FileSet that associated with this Lexer.
+If it is set, Position of tokens and errors will have the name of the file,
+and File records heads of lines for FileSet.Position.
+
+States is a map of State for start conditions.
+Lexer uses Whitespace and TokenTypes of the current state instead of Lexer's ones,
+unless the current state is DefaultState.
+The state will be changed by StateTokenType, or by PushState, PopState and SwitchState.
+
+If Lossless is true, skipped whitespaces and comments are kept in Token.LeadingTrivia and Token.TrailingTrivia.
+Trailing trivia is trivia after the token until the end of line, and the rest is leading trivia of the next token.
+Concatenation of trivia and literals of all tokens is the same as the input.
+Trivia at the end of input is attached to the last token, and errors skipped by SkipError are kept as trivia too.
+If the input has only trivia, like whitespaces or comments, Lexer emits an EOF token that has empty literal and keeps them as leading trivia.
+
+Indentation is a setting of the offside rule, for indentation-sensitive languages like Python.
+Lexer emits INDENT, DEDENT and NEWLINE tokens if it is set. Please read document of Indentation.
+
+Lexer reads all the rest of input before finding a token if the current rules have FuncTokenType,
+even if it is wrapped in StateTokenType or CompiledTokenType.
+So it blocks until the end of input on interactive readers like os.Stdin in this case.
 */
 type Lexer struct {
-	reader     io.Reader
-	buf        string
+	reader      io.Reader
+	buf         string
+	bufOffset   int
+	eof         bool
+	inMemory    bool
+	readErr     error
+	readChunk   []byte
+	err         error
+	cur         cursor
+	queue       tokenRing
+	errors      []error
+	marks       map[int]int
+	markID      int
+	Whitespace  TokenType
+	TokenTypes  []TokenType
+	Strategy    MatchStrategy
+	Columns     ColumnUnits
+	TabWidth    int
+	Recovery    RecoveryMode
+	MaxErrors   int
+	File        *File
+	States      map[string]*State
+	Lossless    bool
+	Indentation *Indentation
+}
+
+// cursor is a scanning point in the input.
+type cursor struct {
+	offset     int
+	pos        Position
+	lineOffset int
 	loadedLine string
-	nextPos    Position
-	Whitespace TokenType
-	TokenTypes []TokenType
+	stateStack []string
+	errorCount int
+	indent     indentState
 }
 
 // Make a new Lexer.
@@ -51,115 +136,544 @@ func NewLexer(reader io.Reader) *Lexer {
 	return l
 }
 
-func (l *Lexer) readBufIfNeed() {
-	if len(l.buf) < 1024 {
-		buf := make([]byte, 2048)
-		l.reader.Read(buf)
-		l.buf += strings.TrimRight(string(buf), "\x00")
+/*
+Make a new Lexer that reads s directly.
+
+The Lexer doesn't copy s, so literals of tokens are substrings of s.
+It makes less allocations than NewLexer with strings.Reader.
+*/
+func NewLexerString(s string) *Lexer {
+	l := NewLexer(nil)
+	l.buf = s
+	l.eof = true
+	l.inMemory = true
+	return l
+}
+
+/*
+Make a new Lexer that reads b directly, like NewLexerString.
+
+The Lexer doesn't copy b, so literals of tokens share the memory with b.
+Please don't modify b while using the Lexer and the tokens.
+*/
+func NewLexerBytes(b []byte) *Lexer {
+	return NewLexerString(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+/*
+readBufIfNeed reads from reader until the buffer has enough data or reached to EOF.
+
+Returns ReaderError if reader returned an error except io.EOF.
+The input that read before the error is treated as the whole input,
+so the error is returned after all of the read input was consumed, and will be returned again in the next call.
+Also returns the kept error if Lexer was stopped by another reason, like TooManyErrorsError.
+*/
+func (l *Lexer) readBufIfNeed() error {
+	return l.readBuf(1024)
+}
+
+// readBuf reads from reader until the buffer after the cursor has size bytes or reached to EOF.
+func (l *Lexer) readBuf(size int) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	chunk := l.readChunk[:0]
+	emptyReads := 0
+	for !l.eof && len(l.rest())+len(chunk) < size {
+		chunk = slices.Grow(chunk, 2048)
+		n, err := l.reader.Read(chunk[len(chunk):cap(chunk)])
+		chunk = chunk[:len(chunk)+n]
+
+		switch {
+		case err == io.EOF:
+			l.eof = true
+		case err != nil:
+			l.eof = true
+			l.readErr = err
+		case n == 0:
+			if emptyReads++; emptyReads >= 100 {
+				l.eof = true
+				l.readErr = io.ErrNoProgress
+			}
+		}
+	}
+	l.buf += string(chunk)
+
+	// Keep the chunk for the next read, unless it grew to read all the rest of input.
+	if cap(chunk) <= 4096 {
+		l.readChunk = chunk[:0]
+	}
+
+	if l.readErr != nil && len(l.rest()) == 0 {
+		return ReaderError{Err: l.readErr, Position: l.cur.pos}
+	}
+	return nil
+}
+
+// rest returns the buffered input after the cursor.
+func (l *Lexer) rest() string {
+	return l.buf[l.cur.offset-l.bufOffset:]
+}
+
+// trim drops the buffered input that no longer needed by the consumer and marks. The input of NewLexerString is never dropped.
+func (l *Lexer) trim() {
+	if l.inMemory {
+		return
+	}
+
+	offset := l.consumer().offset
+	for _, o := range l.marks {
+		if o < offset {
+			offset = o
+		}
+	}
+
+	if offset > l.bufOffset {
+		l.buf = l.buf[offset-l.bufOffset:]
+		l.bufOffset = offset
 	}
 }
 
-func (l *Lexer) consumeBuffer(t *Token) {
+func (l *Lexer) consume(t *Token) {
 	if t == nil {
 		return
 	}
 
-	l.buf = l.buf[len(t.Literal):]
+	if l.File != nil {
+		for i, c := range t.Literal {
+			if c == '\n' {
+				l.File.addLine(l.cur.offset + i + 1)
+			}
+		}
+	}
 
-	l.nextPos = shiftPos(l.nextPos, t.Literal)
+	l.cur.pos = shiftColumns(l.cur.pos, t.Literal, l.Columns, l.TabWidth)
+	l.cur.offset += len(t.Literal)
 
-	if idx := strings.LastIndex(t.Literal, "\n"); idx >= 0 {
-		l.loadedLine = t.Literal[idx+1:]
+	idx := strings.LastIndex(t.Literal, "\n")
+	if idx >= 0 {
+		l.cur.lineOffset = l.cur.offset - len(t.Literal) + idx + 1
+	}
+
+	// The line is in the buffer if the input is in memory, so it is not needed to keep it.
+	if l.inMemory {
+		return
+	}
+	if idx >= 0 {
+		l.cur.loadedLine = t.Literal[idx+1:]
 	} else {
-		l.loadedLine += t.Literal
+		l.cur.loadedLine += t.Literal
 	}
 }
 
-func (l *Lexer) skipWhitespace() {
-	if l.Whitespace == nil {
-		return
+// lineHead returns the string from the head of the line to the cursor.
+func (l *Lexer) lineHead(c cursor) string {
+	if l.inMemory {
+		return l.buf[c.lineOffset:c.offset]
 	}
+	return c.loadedLine
+}
 
-	for true {
-		l.readBufIfNeed()
+func (l *Lexer) skipWhitespace(trivia *[]*Token) error {
+	for {
+		if err := l.readBufIfNeed(); err != nil {
+			return err
+		}
 
-		if t := l.Whitespace.FindToken(l.buf, l.nextPos); t != nil {
-			l.consumeBuffer(t)
+		if t := l.findWhitespace(); t != nil {
+			l.skip(t, trivia)
 		} else {
-			break
+			return nil
 		}
 	}
 }
 
-func (l *Lexer) makeError() error {
-	for shift, _ := range l.buf {
-		if l.Whitespace != nil && l.Whitespace.FindToken(l.buf[shift:], l.nextPos) != nil {
-			return UnknownTokenError{
-				Literal:  l.buf[:shift],
-				Position: l.nextPos,
-			}
+/*
+findWhitespace returns whitespace at the cursor, or nil if there is no whitespace.
+
+The whitespace won't include newline if the newline is significant for Lexer.Indentation.
+*/
+func (l *Lexer) findWhitespace() *Token {
+	whitespace, _ := l.rules()
+	if whitespace == nil {
+		return nil
+	}
+
+	t := whitespace.FindToken(l.rest(), l.cur.pos)
+	if t == nil || !l.newlineSignificant() {
+		return t
+	}
+
+	if idx := strings.IndexByte(t.Literal, '\n'); idx == 0 {
+		return nil
+	} else if idx > 0 {
+		t.Literal = t.Literal[:idx]
+	}
+	return t
+}
+
+func (l *Lexer) makeError() UnknownTokenError {
+	whitespace, tokenTypes := l.rules()
+	rest := l.rest()
+
+	for shift, _ := range rest {
+		if rest[shift] == '\n' && l.newlineSignificant() {
+			return l.newUnknownTokenError(rest[:shift])
 		}
 
-		for _, tokenType := range l.TokenTypes {
-			if tokenType.FindToken(l.buf[shift:], l.nextPos) != nil {
-				return UnknownTokenError{
-					Literal:  l.buf[:shift],
-					Position: l.nextPos,
-				}
+		if whitespace != nil && whitespace.FindToken(rest[shift:], l.cur.pos) != nil {
+			return l.newUnknownTokenError(rest[:shift])
+		}
+
+		for _, tokenType := range tokenTypes {
+			if tokenType.FindToken(rest[shift:], l.cur.pos) != nil {
+				return l.newUnknownTokenError(rest[:shift])
 			}
 		}
 	}
 
+	return l.newUnknownTokenError(rest)
+}
+
+func (l *Lexer) newUnknownTokenError(literal string) UnknownTokenError {
 	return UnknownTokenError{
-		Literal:  l.buf,
-		Position: l.nextPos,
+		Literal:  literal,
+		Position: l.cur.pos,
+		Line:     l.lineAt(l.cur),
 	}
 }
 
+func (l *Lexer) findToken() *Token {
+	var found *Token
+
+	_, tokenTypes := l.rules()
+	rest := l.rest()
+
+	for _, tokenType := range tokenTypes {
+		var t *Token
+		if ctt, ok := tokenType.(*CompiledTokenType); ok && l.Strategy == LongestMatch {
+			t = ctt.findLongest(rest, l.cur.pos)
+		} else {
+			t = tokenType.FindToken(rest, l.cur.pos)
+		}
+		if t == nil {
+			continue
+		}
+
+		if l.Strategy != LongestMatch {
+			return t
+		}
+
+		if found == nil || len(t.Literal) > len(found.Literal) {
+			found = t
+		}
+	}
+
+	return found
+}
+
+// needsAllInput reports whether the current rules have FuncTokenType, that needs all the rest of input.
+func (l *Lexer) needsAllInput() bool {
+	_, tokenTypes := l.rules()
+	for _, tokenType := range tokenTypes {
+		if needsAllInput(tokenType) {
+			return true
+		}
+	}
+	return false
+}
+
+// allInputNeeder is a TokenType that might need all the rest of input, like FuncTokenType or TokenTypes wrapping it.
+type allInputNeeder interface {
+	needsAllInput() bool
+}
+
+func needsAllInput(tokenType TokenType) bool {
+	n, ok := tokenType.(allInputNeeder)
+	return ok && n.needsAllInput()
+}
+
 /*
-Peek the first token in the buffer.
+findLongToken finds a token like findToken, but reads more input if the token reached to the end of the buffer.
 
-Returns nil as *Token if the buffer is empty.
+The token might be longer than the buffer, so it has to be found again with more input.
+The buffer might end in the middle of a rune too, so the token is found again if a partial rune follows it.
 */
-func (l *Lexer) Peek() (*Token, error) {
-	for _, tokenType := range l.TokenTypes {
-		l.skipWhitespace()
+func (l *Lexer) findLongToken() (*Token, error) {
+	if !l.eof && l.needsAllInput() {
+		if err := l.readBuf(math.MaxInt); err != nil {
+			return nil, err
+		}
+	}
 
-		l.readBufIfNeed()
-		if t := tokenType.FindToken(l.buf, l.nextPos); t != nil {
+	for {
+		t := l.findToken()
+		rest := l.rest()
+		if l.eof {
 			return t, nil
 		}
+		if t == nil && (rest == "" || utf8.FullRuneInString(rest)) {
+			return nil, nil
+		}
+		if t != nil && len(t.Literal) < len(rest) && utf8.FullRuneInString(rest[len(t.Literal):]) {
+			return t, nil
+		}
+
+		if err := l.readBuf(len(rest) * 2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+/*
+fixErrorColumns recomputes columns of the position in Token.Err.
+
+TokenTypes count only bytes for positions in errors, because they don't know Lexer.Columns and Lexer.TabWidth.
+*/
+func (l *Lexer) fixErrorColumns(t *Token) {
+	if l.Columns == 0 {
+		return
+	}
+
+	switch err := t.Err.(type) {
+	case InvalidEscapeError:
+		err.Position = l.positionIn(t, err.Position)
+		t.Err = err
+	case MalformedNumberError:
+		err.Position = l.positionIn(t, err.Position)
+		t.Err = err
+	}
+}
+
+// positionIn computes columns of p that is a byte position in the literal of t.
+func (l *Lexer) positionIn(t *Token, p Position) Position {
+	offset := 0
+	for line := t.Position.Line; line < p.Line; line++ {
+		idx := strings.IndexByte(t.Literal[offset:], '\n')
+		if idx < 0 {
+			return p
+		}
+		offset += idx + 1
+	}
+
+	if p.Line == t.Position.Line {
+		offset += p.Column - t.Position.Column
+	} else {
+		offset += p.Column
+	}
+	if offset < 0 || offset > len(t.Literal) {
+		return p
 	}
 
-	if len(l.buf) > 0 {
-		return nil, l.makeError()
+	return shiftColumns(t.Position, t.Literal[:offset], l.Columns, l.TabWidth)
+}
+
+func (l *Lexer) setSpan(t *Token) {
+	t.End = shiftColumns(t.Position, t.Literal, l.Columns, l.TabWidth)
+	t.Offset = l.cur.offset
+	t.EndOffset = l.cur.offset + len(t.Literal)
+}
+
+/*
+next finds a token at the cursor and moves the cursor after the token.
+
+Returns nil entry at the end of input.
+If the state of Lexer can not be changed by the token, the entry has the error as fail and the cursor won't be moved, even over the leading trivia.
+*/
+func (l *Lexer) next() (*lookahead, error) {
+	if l.File != nil {
+		l.cur.pos.Filename = l.File.Name()
+	}
+
+	entry := &lookahead{before: l.cur}
+	var trivia []*Token
+
+	for {
+		if err := l.skipWhitespace(&trivia); err != nil {
+			return nil, err
+		}
+
+		if l.Indentation != nil {
+			t, err := l.offsideToken(&trivia)
+			if err != nil {
+				return nil, err
+			}
+			if t != nil {
+				l.attachTrivia(t, trivia)
+				entry.token = t
+				return entry, nil
+			}
+		}
+
+		t, err := l.findLongToken()
+		if err != nil {
+			return nil, err
+		}
+
+		if t != nil && t.Err == nil && t.trivia {
+			l.skip(t, &trivia)
+			continue
+		}
+
+		if t != nil && t.Err == nil {
+			if l.Indentation != nil && !l.cur.indent.midLine && l.cur.indent.depth == 0 {
+				it, err := l.indentToken()
+				if err != nil {
+					return nil, err
+				}
+				if it != nil {
+					l.attachTrivia(it, trivia)
+					entry.token = it
+					return entry, nil
+				}
+			}
+
+			l.setSpan(t)
+			entry.token = t
+
+			if t.transition != nil {
+				if err := l.checkTransition(t.transition); err != nil {
+					// Move back before the leading trivia, so they are found again with the token when retried.
+					l.restore(entry.before)
+					entry.fail = err
+					return entry, nil
+				}
+				l.applyTransition(t.transition)
+			}
+
+			l.consume(t)
+			l.trackIndent(t)
+			l.attachTrivia(t, trivia)
+			return entry, nil
+		}
+
+		if t == nil {
+			if len(l.rest()) == 0 {
+				if len(trivia) == 0 {
+					return nil, nil
+				}
+
+				t = &Token{Type: EOFTokenType, Position: l.cur.pos}
+				l.setSpan(t)
+				t.LeadingTrivia = trivia
+				entry.token = t
+				return entry, nil
+			}
+
+			e := l.makeError()
+			t = &Token{
+				Type:     ErrorTokenType,
+				Literal:  e.Literal,
+				Position: e.Position,
+				Err:      e,
+			}
+		}
+
+		if t.Literal == "" {
+			// Skip at least one character for avoiding infinite loop.
+			_, size := utf8.DecodeRuneInString(l.rest())
+			if size == 0 {
+				return nil, nil
+			}
+			t.Literal = l.rest()[:size]
+		}
+
+		l.fixErrorColumns(t)
+
+		switch l.Recovery {
+		case EmitErrorToken:
+			if e := l.recordError(t.Err, t.Position); e != nil {
+				return nil, e
+			}
+
+			l.setSpan(t)
+			l.consume(t)
+			l.trackIndent(t)
+			l.attachTrivia(t, trivia)
+
+			entry.token = t
+			return entry, nil
+		case SkipError:
+			if e := l.recordError(t.Err, t.Position); e != nil {
+				return nil, e
+			}
+			l.skip(t, &trivia)
+		default:
+			return nil, t.Err
+		}
 	}
+}
+
+/*
+Peek the first token in the buffer.
 
-	return nil, nil
+Returns nil as *Token if the buffer is empty.
+
+Peek sets Token.End, Token.Offset and Token.EndOffset of the found token.
+
+Returns UnknownTokenError if no TokenType matched, unless Lexer.Recovery is set.
+Also returns Token.Err if TokenType found a malformed token, like UnterminatedStringError.
+Please read document of RecoveryMode about recovery.
+
+If the reader of Lexer returned an error except io.EOF, Peek returns it as ReaderError after tokens that read before the error.
+
+Peek is the same as LookAhead(0).
+*/
+func (l *Lexer) Peek() (*Token, error) {
+	return l.LookAhead(0)
 }
 
 /*
 Scan will get the first token in the buffer and remove it from the buffer.
 
 This function using Lexer.Peek. Please read document of Peek.
+
+If the token was found by StateTokenType, Scan changes the state of Lexer.
+Returns UnknownStateError or StateStackError without consuming the token if failed to change state.
 */
 func (l *Lexer) Scan() (*Token, error) {
-	t, e := l.Peek()
+	err := l.fill(1)
+	if l.queue.len() == 0 {
+		return nil, err
+	}
+
+	entry := l.queue.pop()
+	if entry.fail != nil {
+		return nil, entry.fail
+	}
 
-	l.consumeBuffer(t)
+	l.trim()
 
-	return t, e
+	return entry.token, nil
+}
+
+// consumer returns the cursor after the last scanned token.
+func (l *Lexer) consumer() cursor {
+	if l.queue.len() > 0 {
+		return l.queue.at(0).before
+	}
+	return l.cur
+}
+
+// lineAt returns the line that includes the cursor.
+func (l *Lexer) lineAt(c cursor) string {
+	l.readBufIfNeed()
+
+	rest := l.buf[c.offset-l.bufOffset:]
+	if idx := strings.Index(rest, "\n"); idx >= 0 {
+		rest = rest[:idx]
+	}
+
+	if l.inMemory {
+		return l.buf[c.lineOffset : c.offset+len(rest)]
+	}
+	return c.loadedLine + rest
 }
 
 /*
 GetCurrentLine returns line of last scanned token.
 */
 func (l *Lexer) GetLastLine() string {
-	l.readBufIfNeed()
-
-	if idx := strings.Index(l.buf, "\n"); idx >= 0 {
-		return l.loadedLine + l.buf[:strings.Index(l.buf, "\n")]
-	} else {
-		return l.loadedLine + l.buf
-	}
+	return l.lineAt(l.consumer())
 }
@@ -1,8 +1,12 @@
 package simplexer_test
 
 import (
+	"errors"
+	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"unsafe"
 
 	"github.com/macrat/simplexer"
 )
@@ -15,8 +19,17 @@ type want struct {
 }
 
 func execute(t *testing.T, input string, wants []want) {
-	lexer := simplexer.NewLexer(strings.NewReader(input))
+	executeLexer(t, simplexer.NewLexer(strings.NewReader(input)), wants)
+
+	compiled := simplexer.NewLexer(strings.NewReader(input))
+	compiled.TokenTypes = []simplexer.TokenType{simplexer.NewCompiledTokenType(compiled.TokenTypes)}
+	executeLexer(t, compiled, wants)
+
+	executeLexer(t, simplexer.NewLexerString(input), wants)
+	executeLexer(t, simplexer.NewLexerBytes([]byte(input)), wants)
+}
 
+func executeLexer(t *testing.T, lexer *simplexer.Lexer, wants []want) {
 	for _, except := range wants {
 		token, err := lexer.Scan()
 		if err != nil {
@@ -288,3 +301,352 @@ func TestLexer_Whitespace(t *testing.T) {
 		t.Errorf("excepted \"c\" but got %#v", tok.Literal)
 	}
 }
+
+func TestLexer_nullCharacter(t *testing.T) {
+	execute(t, "a\x00b", []want{
+		{
+			TypeID:   simplexer.IDENT,
+			Literal:  "a",
+			Pos:      simplexer.Position{Line: 0, Column: 0},
+			LastLine: "a\x00b",
+		},
+		{
+			TypeID:   simplexer.OTHER,
+			Literal:  "\x00",
+			Pos:      simplexer.Position{Line: 0, Column: 1},
+			LastLine: "a\x00b",
+		},
+		{
+			TypeID:   simplexer.IDENT,
+			Literal:  "b",
+			Pos:      simplexer.Position{Line: 0, Column: 2},
+			LastLine: "a\x00b",
+		},
+	})
+}
+
+func TestLexer_shortRead(t *testing.T) {
+	input := strings.Repeat("abc def\n", 500)
+	lexer := simplexer.NewLexer(iotest.OneByteReader(strings.NewReader(input)))
+
+	count := 0
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		if token.Literal != "abc" && token.Literal != "def" {
+			t.Fatalf("excepted \"abc\" or \"def\" but got %#v", token.Literal)
+		}
+		count++
+	}
+
+	if count != 1000 {
+		t.Errorf("excepted 1000 tokens but got %d", count)
+	}
+}
+
+func TestLexer_readerError(t *testing.T) {
+	readErr := errors.New("test error")
+	lexer := simplexer.NewLexer(iotest.DataErrReader(io.MultiReader(
+		strings.NewReader("hello world"),
+		iotest.ErrReader(readErr),
+	)))
+
+	for _, except := range []string{"hello", "world"} {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("excepted %#v but got error %s", except, err)
+		}
+		if token == nil || token.Literal != except {
+			t.Fatalf("excepted %#v but got %v", except, token)
+		}
+	}
+
+	token, err := lexer.Scan()
+	if token != nil {
+		t.Errorf("excepted nil but got %#v", token)
+	}
+
+	except := simplexer.ReaderError{Err: readErr, Position: simplexer.Position{Column: 11}}
+	if err != except {
+		t.Fatalf("excepted %#v but got %#v", except, err)
+	}
+	if !errors.Is(err, readErr) {
+		t.Errorf("excepted wrapped %#v but got %#v", readErr, err)
+	}
+
+	if _, err2 := lexer.Scan(); err2 != err {
+		t.Errorf("excepted same error %#v but got %#v", err, err2)
+	}
+}
+
+func TestLexer_LongestMatch(t *testing.T) {
+	const (
+		IF simplexer.TokenID = iota
+		EQ
+		ASSIGN
+	)
+
+	lexer := simplexer.NewLexer(strings.NewReader("if iffy == f"))
+	lexer.Strategy = simplexer.LongestMatch
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewPatternTokenType(IF, []string{"if"}),
+		simplexer.NewPatternTokenType(ASSIGN, []string{"="}),
+		simplexer.NewPatternTokenType(EQ, []string{"=="}),
+	}, lexer.TokenTypes...)
+
+	wants := []struct {
+		TypeID  simplexer.TokenID
+		Literal string
+	}{
+		{IF, "if"},
+		{simplexer.IDENT, "iffy"},
+		{EQ, "=="},
+		{simplexer.IDENT, "f"},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token type=%s literal=%#v but got nil", except.TypeID, except.Literal)
+		}
+
+		if token.Type.GetID() != except.TypeID {
+			t.Errorf("excepted type %s but got %s", except.TypeID, token.Type.GetID())
+		}
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+	}
+
+	if token, err := lexer.Scan(); token != nil || err != nil {
+		t.Errorf("excepted end but got %#v and %#v", token, err)
+	}
+}
+
+func TestLexer_tokenSpan(t *testing.T) {
+	input := "abc \"multi\nline\"\n  12"
+	lexer := simplexer.NewLexer(strings.NewReader(input))
+
+	wants := []struct {
+		Literal   string
+		Pos       simplexer.Position
+		End       simplexer.Position
+		Offset    int
+		EndOffset int
+	}{
+		{"abc", simplexer.Position{Line: 0, Column: 0}, simplexer.Position{Line: 0, Column: 3}, 0, 3},
+		{"\"multi\nline\"", simplexer.Position{Line: 0, Column: 4}, simplexer.Position{Line: 1, Column: 5}, 4, 16},
+		{"12", simplexer.Position{Line: 2, Column: 2}, simplexer.Position{Line: 2, Column: 4}, 19, 21},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token %#v but got nil", except.Literal)
+		}
+
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+		if token.Position != except.Pos {
+			t.Errorf("excepted position %s but got %s", except.Pos, token.Position)
+		}
+		if token.End != except.End {
+			t.Errorf("excepted end position %s but got %s", except.End, token.End)
+		}
+		if token.Offset != except.Offset || token.EndOffset != except.EndOffset {
+			t.Errorf("excepted offset %d-%d but got %d-%d", except.Offset, except.EndOffset, token.Offset, token.EndOffset)
+		}
+		if s := input[token.Offset:token.EndOffset]; s != token.Literal {
+			t.Errorf("excepted source %#v but got %#v", token.Literal, s)
+		}
+	}
+}
+
+func TestLexer_Columns(t *testing.T) {
+	input := "名前 = \"\U0001F600\tx\" é́\n\t値"
+	lexer := simplexer.NewLexer(strings.NewReader(input))
+	lexer.Columns = simplexer.AllColumns
+	lexer.TabWidth = 4
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.STRING, `"[^"]*"`),
+		simplexer.NewRegexpTokenType(simplexer.OTHER, `\PM\pM*`),
+	}
+
+	wants := []struct {
+		Literal string
+		Pos     simplexer.Position
+	}{
+		{"名", simplexer.Position{Line: 0, Column: 0, RuneColumn: 0, UTF16Column: 0, DisplayColumn: 0}},
+		{"前", simplexer.Position{Line: 0, Column: 3, RuneColumn: 1, UTF16Column: 1, DisplayColumn: 2}},
+		{"=", simplexer.Position{Line: 0, Column: 7, RuneColumn: 3, UTF16Column: 3, DisplayColumn: 5}},
+		{"\"\U0001F600\tx\"", simplexer.Position{Line: 0, Column: 9, RuneColumn: 5, UTF16Column: 5, DisplayColumn: 7}},
+		{"é́", simplexer.Position{Line: 0, Column: 18, RuneColumn: 11, UTF16Column: 12, DisplayColumn: 15}},
+		{"値", simplexer.Position{Line: 1, Column: 1, RuneColumn: 1, UTF16Column: 1, DisplayColumn: 4}},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token %#v but got nil", except.Literal)
+		}
+
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+		if token.Position != except.Pos {
+			t.Errorf("%#v: excepted position %#v but got %#v", token.Literal, except.Pos, token.Position)
+		}
+	}
+}
+
+func TestNewLexerString_zeroCopy(t *testing.T) {
+	input := "hello world\n  foo(bar)"
+	head := uintptr(unsafe.Pointer(unsafe.StringData(input)))
+
+	tokens, err := simplexer.NewLexerString(input).Tokens()
+	if err != nil {
+		t.Fatalf("unexcepted error: %s", err)
+	}
+	if s := literals(tokens); s != "hello,world,foo,(,bar,)" {
+		t.Fatalf("excepted \"hello,world,foo,(,bar,)\" but got %#v", s)
+	}
+
+	for _, token := range tokens {
+		p := uintptr(unsafe.Pointer(unsafe.StringData(token.Literal)))
+		if p != head+uintptr(token.Offset) {
+			t.Errorf("%s: excepted a substring of the input at %d", token, token.Offset)
+		}
+	}
+
+	b := []byte(input)
+	tokens, err = simplexer.NewLexerBytes(b).Tokens()
+	if err != nil {
+		t.Fatalf("unexcepted error: %s", err)
+	}
+	for _, token := range tokens {
+		if unsafe.StringData(token.Literal) != &b[token.Offset] {
+			t.Errorf("%s: excepted to share memory with the input at %d", token, token.Offset)
+		}
+	}
+}
+
+func TestNewLexerString_sameAsReader(t *testing.T) {
+	input := "if x:\n    y = 1 @\n  z\n"
+
+	scan := func(lexer *simplexer.Lexer) string {
+		lexer.TokenTypes = append(simplexer.DefaultTokenTypes[:3:3], simplexer.NewPatternTokenType(simplexer.OTHER, []string{":", "="}))
+		lexer.Indentation = simplexer.NewIndentation()
+		lexer.Recovery = simplexer.EmitErrorToken
+
+		var buf strings.Builder
+		for token, err := range lexer.All() {
+			if err != nil {
+				buf.WriteString(err.Error())
+				break
+			}
+			buf.WriteString(token.String() + " " + token.Position.String() + " " + lexer.GetLastLine() + "\n")
+		}
+		for _, err := range lexer.Errors() {
+			buf.WriteString(err.Error() + "\n")
+		}
+		return buf.String()
+	}
+
+	except := scan(simplexer.NewLexer(strings.NewReader(input)))
+	if got := scan(simplexer.NewLexerString(input)); got != except {
+		t.Errorf("excepted\n%s\nbut got\n%s", except, got)
+	}
+	if !strings.Contains(except, "IndentationError") || !strings.Contains(except, "UnknownTokenError") {
+		t.Errorf("excepted errors but got\n%s", except)
+	}
+}
+
+func TestLexer_longToken(t *testing.T) {
+	ident := strings.Repeat("x", 5000)
+	number := strings.Repeat("1", 3000)
+	str := "\"" + strings.Repeat("y", 1498) + "\""
+	input := ident + " " + number + " " + str
+
+	for _, lexer := range []*simplexer.Lexer{
+		simplexer.NewLexer(strings.NewReader(input)),
+		simplexer.NewLexer(iotest.HalfReader(strings.NewReader(input))),
+		simplexer.NewLexerString(input),
+	} {
+		tokens, err := lexer.Tokens()
+		if err != nil {
+			t.Fatalf("unexcepted error: %s", err)
+		}
+		if len(tokens) != 3 || tokens[0].Literal != ident || tokens[1].Literal != number || tokens[2].Literal != str || tokens[2].Type.GetID() != simplexer.STRING {
+			t.Errorf("excepted IDENT, NUMBER and STRING but got %d tokens", len(tokens))
+		}
+	}
+}
+
+func TestLexer_runeAtBufferEnd(t *testing.T) {
+	input := strings.Repeat("a", 1023) + "é b"
+
+	for _, lexer := range []*simplexer.Lexer{
+		simplexer.NewLexer(iotest.OneByteReader(strings.NewReader(input))),
+		simplexer.NewLexerString(input),
+	} {
+		lexer.TokenTypes = []simplexer.TokenType{simplexer.NewRegexpTokenType(simplexer.IDENT, `\p{L}+`)}
+
+		tokens, err := lexer.Tokens()
+		if err != nil {
+			t.Fatalf("unexcepted error: %s", err)
+		}
+		if s := literals(tokens); s != strings.Repeat("a", 1023)+"é,b" {
+			t.Errorf("excepted a word and \"b\" but got %#v", s)
+		}
+	}
+}
+
+func TestLexer_emojiColumns(t *testing.T) {
+	input := "\"\U0001F44D\U0001F3FD\" \"\U0001F1EF\U0001F1F5\U0001F1FA\" \"\U0001F468‍\U0001F469\" \U0001F3FD x"
+	lexer := simplexer.NewLexerString(input)
+	lexer.Columns = simplexer.DisplayColumns
+
+	wants := []struct {
+		Literal string
+		Column  int
+	}{
+		{"\"\U0001F44D\U0001F3FD\"", 0},
+		{"\"\U0001F1EF\U0001F1F5\U0001F1FA\"", 5},
+		{"\"\U0001F468‍\U0001F469\"", 12},
+		{"\U0001F3FD", 17},
+		{"x", 20},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token %#v but got nil", except.Literal)
+		}
+
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+		if token.Position.DisplayColumn != except.Column {
+			t.Errorf("%#v: excepted display column %d but got %d", token.Literal, except.Column, token.Position.DisplayColumn)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package simplexer
+
+// lookahead is a token that found before scanned.
+type lookahead struct {
+	token  *Token
+	before cursor
+	fail   error
+}
+
+// tokenRing is a ring buffer of lookahead tokens.
+type tokenRing struct {
+	items []*lookahead
+	head  int
+	size  int
+}
+
+func (r *tokenRing) len() int {
+	return r.size
+}
+
+func (r *tokenRing) at(i int) *lookahead {
+	return r.items[(r.head+i)%len(r.items)]
+}
+
+func (r *tokenRing) push(x *lookahead) {
+	if r.size == len(r.items) {
+		items := make([]*lookahead, len(r.items)*2+4)
+		for i := 0; i < r.size; i++ {
+			items[i] = r.at(i)
+		}
+		r.items = items
+		r.head = 0
+	}
+
+	r.items[(r.head+r.size)%len(r.items)] = x
+	r.size++
+}
+
+func (r *tokenRing) pop() *lookahead {
+	x := r.items[r.head]
+	r.items[r.head] = nil
+	r.head = (r.head + 1) % len(r.items)
+	r.size--
+	return x
+}
+
+func (r *tokenRing) clear() {
+	for i := range r.items {
+		r.items[i] = nil
+	}
+	r.head = 0
+	r.size = 0
+}
+
+/*
+fill finds tokens until the lookahead buffer has n tokens.
+
+It stops at the end of input, or at a token that has failed to change state.
+*/
+func (l *Lexer) fill(n int) error {
+	for l.queue.len() < n {
+		if l.queue.len() > 0 && l.queue.at(l.queue.len()-1).fail != nil {
+			return nil
+		}
+
+		entry, err := l.next()
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return nil
+		}
+
+		l.queue.push(entry)
+	}
+
+	return nil
+}
+
+// rewind discards lookahead tokens and moves the cursor back to c.
+func (l *Lexer) rewind(c cursor) {
+	l.queue.clear()
+	l.restore(c)
+}
+
+// restore moves the cursor back to c, and forgets errors that recorded after c.
+func (l *Lexer) restore(c cursor) {
+	l.cur = c
+
+	if len(l.errors) > c.errorCount {
+		l.errors = l.errors[:c.errorCount]
+	}
+	if _, ok := l.err.(TooManyErrorsError); ok && (l.MaxErrors <= 0 || len(l.errors) <= l.MaxErrors) {
+		l.err = nil
+	}
+}
+
+/*
+LookAhead returns the k-th token from the next token without consuming, like Peek.
+LookAhead(0) is the same as Peek.
+
+Found tokens are kept in the lookahead buffer, and Scan will return them without finding again.
+So changing Whitespace or TokenTypes does not affect tokens that already looked ahead.
+Lexer changes the state for tokens after StateTokenType while looking ahead.
+
+Returns nil as *Token if the input ends before the k-th token.
+Returns the error if failed to find tokens before the k-th token.
+*/
+func (l *Lexer) LookAhead(k int) (*Token, error) {
+	err := l.fill(k + 1)
+
+	if k < l.queue.len() {
+		return l.queue.at(k).token, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if n := l.queue.len(); n > 0 && l.queue.at(n-1).fail != nil {
+		return nil, l.queue.at(n - 1).fail
+	}
+
+	return nil, nil
+}
+
+/*
+PeekN returns the next n tokens without consuming.
+
+Returns fewer than n tokens if the input ends.
+If failed to find a token, returns tokens before it and the error.
+*/
+func (l *Lexer) PeekN(n int) ([]*Token, error) {
+	tokens := make([]*Token, 0, n)
+
+	for i := 0; i < n; i++ {
+		t, err := l.LookAhead(i)
+		if err != nil {
+			return tokens, err
+		}
+		if t == nil {
+			break
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,153 @@
+package simplexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func literals(tokens []*simplexer.Token) string {
+	ss := make([]string, len(tokens))
+	for i, t := range tokens {
+		ss[i] = t.Literal
+	}
+	return strings.Join(ss, ",")
+}
+
+func TestLexer_PeekN(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a b\nc d\ne"))
+
+	tokens, err := lexer.PeekN(3)
+	if err != nil {
+		t.Fatalf("failed peek: %s", err.Error())
+	}
+	if s := literals(tokens); s != "a,b,c" {
+		t.Errorf("excepted \"a,b,c\" but got %#v", s)
+	}
+
+	if tok, err := lexer.LookAhead(3); err != nil || tok == nil || tok.Literal != "d" {
+		t.Errorf("excepted \"d\" but got %#v and %#v", tok, err)
+	}
+
+	if tok, err := lexer.LookAhead(10); err != nil || tok != nil {
+		t.Errorf("excepted end but got %#v and %#v", tok, err)
+	}
+
+	if line := lexer.GetLastLine(); line != "a b" {
+		t.Errorf("excepted last line \"a b\" but got %#v", line)
+	}
+
+	wants := []struct {
+		Literal  string
+		LastLine string
+		Pos      simplexer.Position
+	}{
+		{"a", "a b", simplexer.Position{Line: 0, Column: 0}},
+		{"b", "a b", simplexer.Position{Line: 0, Column: 2}},
+		{"c", "c d", simplexer.Position{Line: 1, Column: 0}},
+		{"d", "c d", simplexer.Position{Line: 1, Column: 2}},
+		{"e", "e", simplexer.Position{Line: 2, Column: 0}},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted %#v but got nil", except.Literal)
+		}
+
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+		if token.Position != except.Pos {
+			t.Errorf("excepted position %s but got %s", except.Pos, token.Position)
+		}
+		if line := lexer.GetLastLine(); line != except.LastLine {
+			t.Errorf("excepted last line %#v but got %#v", except.LastLine, line)
+		}
+	}
+
+	if tokens, err := lexer.PeekN(2); err != nil || len(tokens) != 0 {
+		t.Errorf("excepted end but got %v and %#v", tokens, err)
+	}
+}
+
+func TestLexer_LookAhead_error(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 2 ! 3"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+	}
+
+	tokens, err := lexer.PeekN(4)
+	if s := literals(tokens); s != "1,2" {
+		t.Errorf("excepted \"1,2\" but got %#v", s)
+	}
+	if _, ok := err.(simplexer.UnknownTokenError); !ok {
+		t.Errorf("excepted UnknownTokenError but got %#v", err)
+	}
+
+	for _, except := range []string{"1", "2"} {
+		if token, err := lexer.Scan(); err != nil || token == nil || token.Literal != except {
+			t.Errorf("excepted %#v but got %#v and %#v", except, token, err)
+		}
+	}
+
+	if _, err := lexer.Scan(); err == nil {
+		t.Errorf("excepted error but got nil")
+	}
+}
+
+func TestLexer_LookAhead_states(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a ( b ) c"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewStateTokenType(simplexer.NewPatternTokenType(0, []string{"("}), simplexer.StatePush, "PAREN"),
+		simplexer.NewRegexpTokenType(1, `[a-z]`),
+	}
+	lexer.States = map[string]*simplexer.State{
+		"PAREN": {
+			Whitespace: simplexer.DefaultWhitespace,
+			TokenTypes: []simplexer.TokenType{
+				simplexer.NewStateTokenType(simplexer.NewPatternTokenType(2, []string{")"}), simplexer.StatePop, ""),
+				simplexer.NewRegexpTokenType(3, `[a-z]`),
+			},
+		},
+	}
+
+	tokens, err := lexer.PeekN(5)
+	if err != nil {
+		t.Fatalf("failed peek: %s", err.Error())
+	}
+
+	ids := []simplexer.TokenID{1, 0, 3, 2, 1}
+	for i, tok := range tokens {
+		if tok.Type.GetID() != ids[i] {
+			t.Errorf("%d: excepted type %s but got %s", i, ids[i], tok.Type.GetID())
+		}
+	}
+
+	if s := lexer.State(); s != simplexer.DefaultState {
+		t.Errorf("excepted state %s before scan but got %s", simplexer.DefaultState, s)
+	}
+
+	lexer.Scan()
+	lexer.Scan()
+
+	if s := lexer.State(); s != "PAREN" {
+		t.Errorf("excepted state PAREN but got %s", s)
+	}
+
+	if err := lexer.SwitchState(simplexer.DefaultState); err != nil {
+		t.Fatalf("failed to switch state: %s", err)
+	}
+
+	if tok, err := lexer.Peek(); err != nil || tok == nil || tok.Type.GetID() != 1 {
+		t.Errorf("excepted token of INITIAL state but got %#v and %#v", tok, err)
+	}
+
+	if _, err := lexer.LookAhead(1); err == nil {
+		t.Errorf("excepted error because \")\" is unknown in INITIAL state, but got nil")
+	}
+}
@@ -0,0 +1,58 @@
+package simplexer
+
+/*
+Mark is a checkpoint of Lexer, for backtracking with Lexer.Reset.
+
+A Mark is alive until released by Lexer.Release.
+Lexer keeps the input after the oldest alive Mark,
+so please release marks that no longer needed for saving memory.
+*/
+type Mark struct {
+	id int
+	c  cursor
+}
+
+// Position returns the position of the next token after the Mark.
+func (m Mark) Position() Position {
+	return m.c.pos
+}
+
+/*
+Mark returns a checkpoint of the current state of Lexer.
+
+Mark records the position after the last scanned token, the line for GetLastLine, the state stack, and recorded errors.
+Tokens that looked ahead by Peek are not included, and will be found again after Reset.
+*/
+func (l *Lexer) Mark() Mark {
+	if l.marks == nil {
+		l.marks = make(map[int]int)
+	}
+
+	l.markID++
+	c := l.consumer()
+	l.marks[l.markID] = c.offset
+
+	return Mark{id: l.markID, c: c}
+}
+
+/*
+Reset restores the state of Lexer to the Mark.
+
+The Mark is still alive after Reset, so it can be used again.
+Returns InvalidMarkError if the Mark was already released.
+*/
+func (l *Lexer) Reset(m Mark) error {
+	if _, ok := l.marks[m.id]; !ok {
+		return InvalidMarkError{Position: m.c.pos}
+	}
+
+	l.rewind(m.c)
+
+	return nil
+}
+
+// Release releases the Mark, and Lexer drops the input that no longer needed.
+func (l *Lexer) Release(m Mark) {
+	delete(l.marks, m.id)
+	l.trim()
+}
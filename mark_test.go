@@ -0,0 +1,165 @@
+package simplexer_test
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/macrat/simplexer"
+)
+
+func TestLexer_MarkReset(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a ( b ) c\nd"))
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewStateTokenType(simplexer.NewPatternTokenType(0, []string{"("}), simplexer.StatePush, "PAREN"),
+		simplexer.NewRegexpTokenType(1, `[a-z]`),
+	}
+	lexer.States = map[string]*simplexer.State{
+		"PAREN": {
+			Whitespace: simplexer.DefaultWhitespace,
+			TokenTypes: []simplexer.TokenType{
+				simplexer.NewStateTokenType(simplexer.NewPatternTokenType(2, []string{")"}), simplexer.StatePop, ""),
+				simplexer.NewRegexpTokenType(3, `[a-z]`),
+			},
+		},
+	}
+
+	lexer.Scan()
+	lexer.Scan()
+
+	mark := lexer.Mark()
+	if p := mark.Position(); p != (simplexer.Position{Line: 0, Column: 3}) {
+		t.Errorf("excepted mark position %s but got %s", simplexer.Position{Line: 0, Column: 3}, p)
+	}
+
+	for i := 0; i < 2; i++ {
+		tokens, err := lexer.Tokens()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if s := literals(tokens); s != "b,),c,d" {
+			t.Errorf("excepted \"b,),c,d\" but got %#v", s)
+		}
+		if tokens[0].Type.GetID() != 3 {
+			t.Errorf("excepted b in PAREN state but got %s", tokens[0].Type.GetID())
+		}
+
+		if line := lexer.GetLastLine(); line != "d" {
+			t.Errorf("excepted last line \"d\" but got %#v", line)
+		}
+
+		if err := lexer.Reset(mark); err != nil {
+			t.Fatalf("failed reset: %s", err)
+		}
+
+		if s := lexer.State(); s != "PAREN" {
+			t.Errorf("excepted state PAREN after reset but got %s", s)
+		}
+		if line := lexer.GetLastLine(); line != "a ( b ) c" {
+			t.Errorf("excepted last line \"a ( b ) c\" but got %#v", line)
+		}
+	}
+
+	lexer.Release(mark)
+
+	if err := lexer.Reset(mark); err == nil {
+		t.Errorf("excepted error but got nil")
+	} else if _, ok := err.(simplexer.InvalidMarkError); !ok {
+		t.Errorf("excepted InvalidMarkError but got %#v", err)
+	}
+}
+
+func TestLexer_MarkReset_errors(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 ! 2 ? 3"))
+	lexer.Recovery = simplexer.SkipError
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+	}
+
+	lexer.Scan()
+	mark := lexer.Mark()
+
+	if tokens, err := lexer.Tokens(); err != nil || literals(tokens) != "2,3" {
+		t.Fatalf("excepted \"2,3\" but got %v and %#v", tokens, err)
+	}
+	if n := len(lexer.Errors()); n != 2 {
+		t.Errorf("excepted 2 errors but got %d", n)
+	}
+
+	lexer.Reset(mark)
+
+	if n := len(lexer.Errors()); n != 0 {
+		t.Errorf("excepted no errors after reset but got %d", n)
+	}
+
+	if tokens, err := lexer.Tokens(); err != nil || literals(tokens) != "2,3" {
+		t.Fatalf("excepted \"2,3\" but got %v and %#v", tokens, err)
+	}
+	if n := len(lexer.Errors()); n != 2 {
+		t.Errorf("excepted 2 errors but got %d", n)
+	}
+}
+
+func TestLexer_MarkReset_errorsCopy(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 ! 2 ? 3"))
+	lexer.Recovery = simplexer.SkipError
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(simplexer.NUMBER, `[0-9]+`),
+	}
+
+	mark := lexer.Mark()
+	lexer.Tokens()
+
+	errs := lexer.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("excepted 2 errors but got %d", len(errs))
+	}
+	first := errs[0]
+
+	lexer.Reset(mark)
+	lexer.TokenTypes = append(lexer.TokenTypes, simplexer.NewPatternTokenType(simplexer.OTHER, []string{"!"}))
+	lexer.Tokens()
+
+	if n := len(lexer.Errors()); n != 1 {
+		t.Errorf("excepted 1 error but got %d", n)
+	}
+	if errs[0] != first {
+		t.Errorf("excepted returned errors are not changed but got %#v", errs)
+	}
+}
+
+func TestLexer_MarkReset_streaming(t *testing.T) {
+	input := strings.Repeat("abc ", 2000)
+	lexer := simplexer.NewLexer(iotest.OneByteReader(strings.NewReader(input)))
+
+	for i := 0; i < 1000; i++ {
+		lexer.Scan()
+	}
+
+	mark := lexer.Mark()
+
+	for i := 0; i < 500; i++ {
+		lexer.Scan()
+	}
+
+	lexer.Reset(mark)
+	lexer.Release(mark)
+
+	count := 0
+	for token, err := range lexer.All() {
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token.Literal != "abc" {
+			t.Fatalf("excepted \"abc\" but got %#v", token.Literal)
+		}
+		if count == 0 && token.Offset != 4000 {
+			t.Errorf("excepted offset 4000 but got %d", token.Offset)
+		}
+		count++
+	}
+
+	if count != 1000 {
+		t.Errorf("excepted 1000 tokens but got %d", count)
+	}
+}
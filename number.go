@@ -0,0 +1,369 @@
+package simplexer
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NumberSyntax is a set of syntaxes of numeric literal.
+type NumberSyntax int
+
+// Syntaxes for NumberTokenType.Syntax.
+const (
+	// HexNumbers enables hexadecimal integer like 0xFF.
+	HexNumbers NumberSyntax = 1 << iota
+
+	// OctalNumbers enables octal integer like 0o755.
+	OctalNumbers
+
+	// BinaryNumbers enables binary integer like 0b1010.
+	BinaryNumbers
+
+	// LegacyOctalNumbers enables octal integer of C like 0755.
+	LegacyOctalNumbers
+
+	// DigitSeparators enables underscores between digits like 1_000_000, or after the prefix like 0x_FF.
+	DigitSeparators
+
+	// Fractions enables floating point number like 1.5, and hexadecimal float like 0x1.8p1 if Exponents is enabled too.
+	// A dot has to be followed by a digit or an exponent, so "1." is not a float but "1.e5" is.
+	Fractions
+
+	// LeadingDotFractions enables floating point number without integer part like .5.
+	LeadingDotFractions
+
+	// Exponents enables exponent like 1e10, and binary exponent of hexadecimal like 0x1p-2.
+	Exponents
+
+	// SignedNumbers enables sign like -1 or +1.
+	// Please be careful, "a-1" will be split into "a" and "-1".
+	SignedNumbers
+
+	// GoNumbers is a set of syntaxes for numeric literal of Go.
+	GoNumbers = HexNumbers | OctalNumbers | BinaryNumbers | DigitSeparators | Fractions | LeadingDotFractions | Exponents
+
+	// CNumbers is a set of syntaxes for numeric literal of C.
+	CNumbers = HexNumbers | LegacyOctalNumbers | Fractions | LeadingDotFractions | Exponents
+)
+
+/*
+NumberTokenType is a TokenType for numeric literals.
+
+ID is TokenID for this token type.
+
+Syntax is a set of NumberSyntax that accepted by this token type.
+Decimal integer is always accepted.
+
+Suffixes is an array of suffixes like "u", "L" or "f".
+Any other letters after the number makes the number malformed.
+
+Found Token has the parsed value as Token.Value.
+The value is int64 for integer, *big.Int for integer that overflows int64, and float64 for float.
+Token.Submatches[0] is the number without suffix, and Token.Submatches[1] is the suffix.
+
+If the number is malformed, the Token has MalformedNumberError as Token.Err, and Token.Value is nil.
+*/
+type NumberTokenType struct {
+	ID       TokenID
+	Syntax   NumberSyntax
+	Suffixes []string
+}
+
+/*
+Make new NumberTokenType.
+
+id is a TokenID of new NumberTokenType.
+
+syntax is a set of NumberSyntax, like GoNumbers.
+*/
+func NewNumberTokenType(id TokenID, syntax NumberSyntax) *NumberTokenType {
+	return &NumberTokenType{
+		ID:     id,
+		Syntax: syntax,
+	}
+}
+
+// Get readable string of TokenID.
+func (ntt *NumberTokenType) String() string {
+	return ntt.ID.String()
+}
+
+// GetID returns id of this token type.
+func (ntt *NumberTokenType) GetID() TokenID {
+	return ntt.ID
+}
+
+func isDecimal(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isDecimal(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// numberScanner scans a numeric literal and remembers the first malformed point.
+type numberScanner struct {
+	s          string
+	i          int
+	separators bool
+	errAt      int
+	errMsg     string
+}
+
+func (ns *numberScanner) fail(at int, msg string) {
+	if ns.errMsg == "" {
+		ns.errAt = ns.i
+		if at >= 0 {
+			ns.errAt = at
+		}
+		ns.errMsg = msg
+	}
+}
+
+func (ns *numberScanner) peek(n int) byte {
+	if ns.i+n < len(ns.s) {
+		return ns.s[ns.i+n]
+	}
+	return 0
+}
+
+/*
+digits scans digits and separators, and returns the number of digits.
+
+If afterPrefix is true, a separator before the first digit is allowed.
+*/
+func (ns *numberScanner) digits(isDigit func(byte) bool, afterPrefix bool) int {
+	n := 0
+	for ns.i < len(ns.s) {
+		c := ns.s[ns.i]
+
+		if c == '_' && ns.separators {
+			prevOK := n > 0 || afterPrefix
+			if !prevOK || !isDigit(ns.peek(1)) {
+				ns.fail(ns.i, "'_' must separate successive digits")
+			}
+			ns.i++
+			continue
+		}
+
+		if !isDigit(c) {
+			break
+		}
+		ns.i++
+		n++
+	}
+	return n
+}
+
+// FindToken returns new Token if s starts with a numeric literal.
+func (ntt *NumberTokenType) FindToken(s string, p Position) *Token {
+	ns := &numberScanner{
+		s:          s,
+		separators: ntt.Syntax&DigitSeparators != 0,
+	}
+
+	if ntt.Syntax&SignedNumbers != 0 && (ns.peek(0) == '+' || ns.peek(0) == '-') {
+		ns.i++
+	}
+	start := ns.i
+
+	base := 10
+	if ns.peek(0) == '0' {
+		switch ns.peek(1) {
+		case 'x', 'X':
+			if ntt.Syntax&HexNumbers != 0 {
+				base = 16
+			}
+		case 'o', 'O':
+			if ntt.Syntax&OctalNumbers != 0 {
+				base = 8
+			}
+		case 'b', 'B':
+			if ntt.Syntax&BinaryNumbers != 0 {
+				base = 2
+			}
+		}
+	}
+
+	isFloat := false
+
+	if base != 10 {
+		ns.i += 2
+		digitsStart := ns.i
+
+		isDigit := isDecimal
+		if base == 16 {
+			isDigit = func(c byte) bool {
+				return hexValue(c) >= 0
+			}
+		}
+
+		n := ns.digits(isDigit, true)
+
+		if base == 16 && ntt.Syntax&(Fractions|Exponents) == Fractions|Exponents && ns.peek(0) == '.' && ns.hexExponentFollows(ns.i+1) {
+			ns.i++
+			n += ns.digits(isDigit, false)
+		}
+
+		if n == 0 {
+			ns.fail(-1, "no digits after the prefix "+s[start:start+2])
+		}
+
+		for i := digitsStart; i < ns.i; i++ {
+			if c := s[i]; c != '_' && c != '.' && hexValue(c) >= base {
+				ns.fail(i, "invalid digit "+strconv.Quote(string(c))+" in "+baseName(base)+" literal")
+			}
+		}
+
+		if base == 16 && ntt.Syntax&Exponents != 0 && (ns.peek(0) == 'p' || ns.peek(0) == 'P') {
+			ns.exponent()
+			isFloat = true
+		}
+	} else {
+		if !isDecimal(ns.peek(0)) && !(ns.peek(0) == '.' && isDecimal(ns.peek(1))) {
+			return nil
+		}
+
+		intDigits := ns.digits(isDecimal, false)
+
+		hasFraction := ntt.Syntax&Fractions != 0 && ns.peek(0) == '.' && isDecimal(ns.peek(1))
+		if intDigits == 0 && !(hasFraction && ntt.Syntax&LeadingDotFractions != 0) {
+			return nil
+		}
+		if intDigits > 0 && ntt.Syntax&(Fractions|Exponents) == Fractions|Exponents && ns.peek(0) == '.' && ns.exponentFollows(ns.i+1) {
+			hasFraction = true
+		}
+
+		if hasFraction {
+			ns.i++
+			ns.digits(isDecimal, false)
+			isFloat = true
+		}
+
+		if ntt.Syntax&Exponents != 0 && (ns.peek(0) == 'e' || ns.peek(0) == 'E') {
+			ns.exponent()
+			isFloat = true
+		}
+
+		if !isFloat && ntt.Syntax&LegacyOctalNumbers != 0 && intDigits > 1 && s[start] == '0' {
+			base = 8
+			for i := start; i < ns.i; i++ {
+				if c := s[i]; c == '8' || c == '9' {
+					ns.fail(i, "invalid digit "+strconv.Quote(string(c))+" in octal literal")
+				}
+			}
+		}
+	}
+
+	number := s[:ns.i]
+
+	suffixStart := ns.i
+	for ns.i < len(s) && isAlnum(s[ns.i]) {
+		ns.i++
+	}
+	suffix := s[suffixStart:ns.i]
+
+	if suffix != "" && !ntt.hasSuffix(suffix) {
+		ns.fail(suffixStart, "invalid suffix "+strconv.Quote(suffix))
+	}
+
+	t := &Token{
+		Type:       ntt,
+		Literal:    s[:ns.i],
+		Submatches: []string{number, suffix},
+		Position:   p,
+	}
+
+	if ns.errMsg != "" {
+		t.Err = MalformedNumberError{
+			Message:  ns.errMsg,
+			Position: shiftPos(p, s[:ns.errAt]),
+		}
+		return t
+	}
+
+	t.Value = parseNumber(number, start, base, isFloat)
+
+	return t
+}
+
+// exponent scans an exponent part that starts with 'e' or 'p'.
+func (ns *numberScanner) exponent() {
+	ns.i++
+	if c := ns.peek(0); c == '+' || c == '-' {
+		ns.i++
+	}
+	if ns.digits(isDecimal, false) == 0 {
+		ns.fail(-1, "exponent has no digits")
+	}
+}
+
+// exponentFollows reports whether a decimal exponent like e5 or E-2 starts at s[i].
+func (ns *numberScanner) exponentFollows(i int) bool {
+	if i >= len(ns.s) || (ns.s[i] != 'e' && ns.s[i] != 'E') {
+		return false
+	}
+	i++
+	if i < len(ns.s) && (ns.s[i] == '+' || ns.s[i] == '-') {
+		i++
+	}
+	return i < len(ns.s) && isDecimal(ns.s[i])
+}
+
+// hexExponentFollows reports whether hexadecimal digits and a binary exponent like p1 start at s[i].
+func (ns *numberScanner) hexExponentFollows(i int) bool {
+	for i < len(ns.s) && (hexValue(ns.s[i]) >= 0 || ns.s[i] == '_') {
+		i++
+	}
+	return i < len(ns.s) && (ns.s[i] == 'p' || ns.s[i] == 'P')
+}
+
+func (ntt *NumberTokenType) hasSuffix(suffix string) bool {
+	for _, x := range ntt.Suffixes {
+		if x == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+func baseName(base int) string {
+	switch base {
+	case 2:
+		return "binary"
+	case 8:
+		return "octal"
+	case 16:
+		return "hexadecimal"
+	default:
+		return "decimal"
+	}
+}
+
+/*
+parseNumber parses a well-formed numeric literal without suffix.
+
+start is the index of the head of the number after the sign.
+*/
+func parseNumber(number string, start, base int, isFloat bool) interface{} {
+	sign := number[:start]
+	digits := strings.ReplaceAll(number[start:], "_", "")
+
+	if isFloat {
+		// strconv.ParseFloat returns ±Inf if the value is out of range. It is acceptable value.
+		f, _ := strconv.ParseFloat(sign+digits, 64)
+		return f
+	}
+
+	if base == 16 || base == 2 || (base == 8 && len(digits) > 1 && (digits[1] == 'o' || digits[1] == 'O')) {
+		digits = digits[2:]
+	}
+
+	if i, err := strconv.ParseInt(sign+digits, base, 64); err == nil {
+		return i
+	}
+
+	b, _ := new(big.Int).SetString(sign+digits, base)
+	return b
+}
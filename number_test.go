@@ -0,0 +1,227 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewNumberTokenType() {
+	lexer := simplexer.NewLexer(strings.NewReader(`0xFF 1_000 .5 1e3 99999999999999999999`))
+
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewNumberTokenType(simplexer.NUMBER, simplexer.GoNumbers),
+	}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%s -> %T %v\n", token.Literal, token.Value, token.Value)
+	}
+
+	// Output:
+	// 0xFF -> int64 255
+	// 1_000 -> int64 1000
+	// .5 -> float64 0.5
+	// 1e3 -> float64 1000
+	// 99999999999999999999 -> *big.Int 99999999999999999999
+}
+
+func TestNumberTokenType(t *testing.T) {
+	all := &simplexer.NumberTokenType{
+		Syntax:   simplexer.GoNumbers | simplexer.SignedNumbers,
+		Suffixes: []string{"u", "L", "f", "uL"},
+	}
+	c := simplexer.NewNumberTokenType(0, simplexer.CNumbers)
+
+	tests := []struct {
+		Type    *simplexer.NumberTokenType
+		Input   string
+		Literal string
+		Value   interface{}
+		Suffix  string
+	}{
+		{all, "123 ", "123", int64(123), ""},
+		{all, "-123", "-123", int64(-123), ""},
+		{all, "+0x1f", "+0x1f", int64(31), ""},
+		{all, "0X_FF_FF", "0X_FF_FF", int64(0xFFFF), ""},
+		{all, "0o755", "0o755", int64(0755), ""},
+		{all, "0b1010uL", "0b1010uL", int64(10), "uL"},
+		{all, "1.5f", "1.5f", 1.5, "f"},
+		{all, "-.25", "-.25", -0.25, ""},
+		{all, "1e3", "1e3", 1000.0, ""},
+		{all, "2.5E-2", "2.5E-2", 0.025, ""},
+		{all, "0x1p-2", "0x1p-2", 0.25, ""},
+		{all, "0x1.8p1", "0x1.8p1", 3.0, ""},
+		{all, "0x.8p1", "0x.8p1", 1.0, ""},
+		{all, "0x1.p1", "0x1.p1", 2.0, ""},
+		{all, "0x1.8", "0x1", int64(1), ""},
+		{all, "1.e5", "1.e5", 100000.0, ""},
+		{all, "1.e", "1", int64(1), ""},
+		{all, "1..2", "1", int64(1), ""},
+		{all, "1.x", "1", int64(1), ""},
+		{all, "9223372036854775807", "9223372036854775807", int64(9223372036854775807), ""},
+		{c, "0755", "0755", int64(0755), ""},
+		{c, "0", "0", int64(0), ""},
+		{c, "09.5", "09.5", 9.5, ""},
+		{simplexer.NewNumberTokenType(0, 0), "1.5", "1", int64(1), ""},
+	}
+
+	pos := simplexer.Position{Line: 1, Column: 2}
+
+	for _, tc := range tests {
+		tok := tc.Type.FindToken(tc.Input, pos)
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Err != nil {
+			t.Errorf("%#v: unexcepted error: %s", tc.Input, tok.Err)
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Value != tc.Value {
+			t.Errorf("%#v: excepted value %#v but got %#v", tc.Input, tc.Value, tok.Value)
+		}
+		if tok.Submatches[1] != tc.Suffix {
+			t.Errorf("%#v: excepted suffix %#v but got %#v", tc.Input, tc.Suffix, tok.Submatches[1])
+		}
+	}
+
+	for _, input := range []string{"abc", "-", "+x", ".5", "_1"} {
+		if tok := simplexer.NewNumberTokenType(0, simplexer.SignedNumbers).FindToken(input, pos); tok != nil {
+			t.Errorf("%#v: excepted nil but got %s", input, tok)
+		}
+	}
+
+	for _, input := range []string{"_1", "-_1", "_.5", "._5"} {
+		if tok := all.FindToken(input, pos); tok != nil {
+			t.Errorf("%#v: excepted nil but got %s", input, tok)
+		}
+	}
+}
+
+func TestNumberTokenType_bigInt(t *testing.T) {
+	ntt := simplexer.NewNumberTokenType(0, simplexer.GoNumbers|simplexer.SignedNumbers)
+
+	tests := []struct {
+		Input string
+		Value string
+	}{
+		{"9223372036854775808", "9223372036854775808"},
+		{"-9223372036854775809", "-9223372036854775809"},
+		{"0xFFFF_FFFF_FFFF_FFFF_FFFF", "1208925819614629174706175"},
+	}
+
+	for _, tc := range tests {
+		tok := ntt.FindToken(tc.Input, simplexer.Position{})
+		if tok == nil || tok.Err != nil {
+			t.Errorf("%#v: excepted token but got %v", tc.Input, tok)
+			continue
+		}
+
+		b, ok := tok.Value.(*big.Int)
+		if !ok {
+			t.Errorf("%#v: excepted *big.Int but got %#v", tc.Input, tok.Value)
+			continue
+		}
+		if b.String() != tc.Value {
+			t.Errorf("%#v: excepted %s but got %s", tc.Input, tc.Value, b)
+		}
+	}
+}
+
+func TestNumberTokenType_malformed(t *testing.T) {
+	goNumbers := simplexer.NewNumberTokenType(0, simplexer.GoNumbers)
+
+	tests := []struct {
+		Type    *simplexer.NumberTokenType
+		Input   string
+		Literal string
+		Column  int
+		Message string
+	}{
+		{goNumbers, "0x ", "0x", 4, "no digits after the prefix 0x"},
+		{goNumbers, "0b1021", "0b1021", 6, "invalid digit \"2\" in binary literal"},
+		{goNumbers, "0o78", "0o78", 5, "invalid digit \"8\" in octal literal"},
+		{goNumbers, "1__000", "1__000", 3, "'_' must separate successive digits"},
+		{goNumbers, "1_ ", "1_", 3, "'_' must separate successive digits"},
+		{goNumbers, "1_.5", "1_.5", 3, "'_' must separate successive digits"},
+		{goNumbers, "0x_", "0x_", 4, "'_' must separate successive digits"},
+		{goNumbers, "1e+;", "1e+", 5, "exponent has no digits"},
+		{goNumbers, "12abc", "12abc", 4, "invalid suffix \"abc\""},
+		{simplexer.NewNumberTokenType(0, simplexer.CNumbers), "0789", "0789", 4, "invalid digit \"8\" in octal literal"},
+		{simplexer.NewNumberTokenType(0, 0), "1_000", "1_000", 3, "invalid suffix \"_000\""},
+	}
+
+	for _, tc := range tests {
+		tok := tc.Type.FindToken(tc.Input, simplexer.Position{Line: 1, Column: 2})
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		except := simplexer.MalformedNumberError{
+			Message:  tc.Message,
+			Position: simplexer.Position{Line: 1, Column: tc.Column},
+		}
+		if tok.Err != except {
+			t.Errorf("%#v: excepted error %#v but got %#v", tc.Input, except, tok.Err)
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Value != nil {
+			t.Errorf("%#v: excepted nil value but got %#v", tc.Input, tok.Value)
+		}
+	}
+}
+
+func TestLexer_malformedNumber(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("x = 1\ny = 0b12"))
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewNumberTokenType(simplexer.NUMBER, simplexer.GoNumbers),
+	}, lexer.TokenTypes...)
+
+	tokens, err := lexer.Tokens()
+	if s := literals(tokens); s != "x,=,1,y,=" {
+		t.Errorf("excepted \"x,=,1,y,=\" but got %#v", s)
+	}
+
+	except := simplexer.MalformedNumberError{
+		Message:  "invalid digit \"2\" in binary literal",
+		Position: simplexer.Position{Line: 1, Column: 7},
+	}
+	if err != except {
+		t.Errorf("excepted %#v but got %#v", except, err)
+	}
+}
+
+func TestLexer_malformedNumber_columns(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("値 = 0b12"))
+	lexer.Columns = simplexer.AllColumns
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewNumberTokenType(simplexer.NUMBER, simplexer.GoNumbers),
+	}, lexer.TokenTypes...)
+
+	_, err := lexer.Tokens()
+
+	except := simplexer.MalformedNumberError{
+		Message:  "invalid digit \"2\" in binary literal",
+		Position: simplexer.Position{Line: 0, Column: 9, RuneColumn: 7, UTF16Column: 7, DisplayColumn: 8},
+	}
+	if err != except {
+		t.Errorf("excepted %#v but got %#v", except, err)
+	}
+}
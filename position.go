@@ -3,19 +3,65 @@ package simplexer
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
-// Position in the file.
+/*
+Position in the file.
+
+Filename is the name of the file if Lexer.File is set, otherwise it is empty.
+
+Line and Column are always counted, and Column is the number of bytes from the head of the line.
+
+RuneColumn, UTF16Column and DisplayColumn are other units of column.
+They are counted only if enabled by Lexer.Columns, otherwise they are 0.
+*/
 type Position struct {
-	Line   int
-	Column int
+	Filename      string
+	Line          int
+	Column        int
+	RuneColumn    int // Column in runes.
+	UTF16Column   int // Column in UTF-16 code units, for LSP clients.
+	DisplayColumn int // Column in cells of terminal, with expanding tabs.
 }
 
+// ColumnUnits is a set of column units to count.
+type ColumnUnits int
+
+// Column units for Lexer.Columns.
+const (
+	// RuneColumns enables Position.RuneColumn.
+	RuneColumns ColumnUnits = 1 << iota
+
+	// UTF16Columns enables Position.UTF16Column.
+	UTF16Columns
+
+	// DisplayColumns enables Position.DisplayColumn.
+	DisplayColumns
+
+	// AllColumns enables all column units.
+	AllColumns = RuneColumns | UTF16Columns | DisplayColumns
+)
+
+// DefaultTabWidth is the width of tab stops that used if Lexer.TabWidth is 0.
+const DefaultTabWidth = 8
+
 // Convert to string.
 func (p Position) String() string {
+	if p.Filename != "" {
+		return fmt.Sprintf("[file:%s, line:%d, column:%d]", p.Filename, p.Line, p.Column)
+	}
 	return fmt.Sprintf("[line:%d, column:%d]", p.Line, p.Column)
 }
 
+// location returns "filename:line:column" for error messages. Line and column are 1-based.
+func (p Position) location() string {
+	if p.Filename != "" {
+		return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line+1, p.Column+1)
+	}
+	return fmt.Sprintf("%d:%d", p.Line+1, p.Column+1)
+}
+
 // Position.Before will check p is before than x.
 func (p Position) Before(x Position) bool {
 	return p.Line < x.Line || (p.Line == x.Line && p.Column < x.Column)
@@ -27,15 +73,68 @@ func (p Position) After(x Position) bool {
 }
 
 func shiftPos(p Position, s string) Position {
-	lines := strings.Split(s, "\n")
-	lineShift := len(lines) - 1
-
-	if lineShift == 0 {
-		p.Column += len(lines[0])
+	if idx := strings.LastIndexByte(s, '\n'); idx >= 0 {
+		p.Line += strings.Count(s, "\n")
+		p.Column = len(s) - idx - 1
 	} else {
-		p.Column = len(lines[len(lines)-1])
+		p.Column += len(s)
+	}
+
+	return p
+}
+
+func shiftColumns(p Position, s string, units ColumnUnits, tabWidth int) Position {
+	p = shiftPos(p, s)
+	if units == 0 {
+		return p
+	}
+
+	if idx := strings.LastIndex(s, "\n"); idx >= 0 {
+		s = s[idx+1:]
+		p.RuneColumn = 0
+		p.UTF16Column = 0
+		p.DisplayColumn = 0
+	}
+
+	if units&RuneColumns != 0 {
+		p.RuneColumn += utf8.RuneCountInString(s)
+	}
+
+	if units&UTF16Columns != 0 {
+		for _, r := range s {
+			if r >= 0x10000 {
+				p.UTF16Column += 2
+			} else {
+				p.UTF16Column++
+			}
+		}
+	}
+
+	if units&DisplayColumns != 0 {
+		if tabWidth <= 0 {
+			tabWidth = DefaultTabWidth
+		}
+
+		var prev rune
+		flag := false
+		for _, r := range s {
+			switch {
+			case r == '\t':
+				p.DisplayColumn = (p.DisplayColumn/tabWidth + 1) * tabWidth
+				flag = false
+			case prev == '\u200D':
+			case isEmojiModifier(r) && isWide(prev):
+				// A skin tone modifier is drawn in the cells of the emoji before it.
+			case isRegionalIndicator(r) && flag:
+				// The second regional indicator of a pair is drawn as one flag with the first.
+				flag = false
+			default:
+				p.DisplayColumn += runeWidth(r)
+				flag = isRegionalIndicator(r)
+			}
+			prev = r
+		}
 	}
-	p.Line += lineShift
 
 	return p
 }
@@ -50,3 +50,9 @@ func TestPositionCompare(t *testing.T) {
 		t.Errorf("Position reports %v is not after of %v", c, b)
 	}
 }
+
+func TestPositionString_withFilename(t *testing.T) {
+	if s := (simplexer.Position{Filename: "test.txt", Line: 1, Column: 2}).String(); s != "[file:test.txt, line:1, column:2]" {
+		t.Errorf("failed convert to string: excepted [file:test.txt, line:1, column:2] but got %#v", s)
+	}
+}
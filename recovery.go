@@ -0,0 +1,43 @@
+package simplexer
+
+/*
+RecoveryMode is a behavior of Lexer when found a sequence that no TokenType matched.
+
+In recovery modes, Lexer records UnknownTokenError or Token.Err of malformed tokens, and continues lexing.
+Recorded errors can be get by Lexer.Errors.
+Errors are recorded when Lexer found them, so errors of tokens that looked ahead by Peek are recorded too.
+If the number of errors exceeded Lexer.MaxErrors, Lexer returns TooManyErrorsError and stops.
+*/
+type RecoveryMode int
+
+const (
+	// NoRecovery returns UnknownTokenError and stops lexing.
+	NoRecovery RecoveryMode = iota
+
+	// EmitErrorToken returns the unknown sequence as a token of ErrorTokenType.
+	// Malformed tokens are returned as is. Token.Err of these tokens is the error.
+	EmitErrorToken
+
+	// SkipError skips the unknown sequence like whitespace.
+	SkipError
+)
+
+func (l *Lexer) recordError(err error, pos Position) error {
+	l.errors = append(l.errors, err)
+	l.cur.errorCount = len(l.errors)
+
+	if l.MaxErrors > 0 && len(l.errors) > l.MaxErrors {
+		l.err = TooManyErrorsError{
+			Count:    len(l.errors),
+			Position: pos,
+		}
+		return l.err
+	}
+
+	return nil
+}
+
+// Errors returns a copy of errors that recorded in recovery mode.
+func (l *Lexer) Errors() []error {
+	return append([]error(nil), l.errors...)
+}
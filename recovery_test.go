@@ -0,0 +1,134 @@
+package simplexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func TestLexer_EmitErrorToken(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 2 error 3 ?? 4"))
+	lexer.Recovery = simplexer.EmitErrorToken
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(0, `^[0-9]+`),
+	}
+
+	wants := []struct {
+		TypeID  simplexer.TokenID
+		Literal string
+	}{
+		{0, "1"},
+		{0, "2"},
+		{simplexer.ERROR, "error"},
+		{0, "3"},
+		{simplexer.ERROR, "??"},
+		{0, "4"},
+	}
+
+	for _, except := range wants {
+		if except.TypeID == simplexer.ERROR {
+			if _, err := lexer.Peek(); err != nil {
+				t.Fatalf("failed peek: %s", err.Error())
+			}
+			before := len(lexer.Errors())
+			if _, err := lexer.Peek(); err != nil {
+				t.Fatalf("failed peek: %s", err.Error())
+			}
+			if len(lexer.Errors()) != before {
+				t.Errorf("Peek recorded the same error twice: %#v", lexer.Errors())
+			}
+		}
+
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token %#v but got nil", except.Literal)
+		}
+
+		if token.Type.GetID() != except.TypeID {
+			t.Errorf("excepted type %s but got %s", except.TypeID, token.Type.GetID())
+		}
+		if token.Literal != except.Literal {
+			t.Errorf("excepted literal %#v but got %#v", except.Literal, token.Literal)
+		}
+	}
+
+	if token, err := lexer.Scan(); token != nil || err != nil {
+		t.Errorf("excepted end but got %#v and %#v", token, err)
+	}
+
+	errs := lexer.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("excepted 2 errors but got %#v", errs)
+	}
+
+	excepts := []simplexer.UnknownTokenError{
+		{Literal: "error", Position: simplexer.Position{Line: 0, Column: 4}, Line: "1 2 error 3 ?? 4"},
+		{Literal: "??", Position: simplexer.Position{Line: 0, Column: 12}, Line: "1 2 error 3 ?? 4"},
+	}
+	for i, except := range excepts {
+		if errs[i] != except {
+			t.Errorf("excepted error %#v but got %#v", except, errs[i])
+		}
+	}
+}
+
+func TestLexer_SkipError(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("1 2 error 3 ?? 4"))
+	lexer.Recovery = simplexer.SkipError
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewRegexpTokenType(0, `^[0-9]+`),
+	}
+
+	for _, except := range []string{"1", "2", "3", "4"} {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token %#v but got nil", except)
+		}
+		if token.Literal != except {
+			t.Errorf("excepted literal %#v but got %#v", except, token.Literal)
+		}
+	}
+
+	if token, err := lexer.Scan(); token != nil || err != nil {
+		t.Errorf("excepted end but got %#v and %#v", token, err)
+	}
+
+	if errs := lexer.Errors(); len(errs) != 2 {
+		t.Errorf("excepted 2 errors but got %#v", errs)
+	}
+}
+
+func TestLexer_MaxErrors(t *testing.T) {
+	for _, mode := range []simplexer.RecoveryMode{simplexer.EmitErrorToken, simplexer.SkipError} {
+		lexer := simplexer.NewLexer(strings.NewReader("a 1 b 2 c 3"))
+		lexer.Recovery = mode
+		lexer.MaxErrors = 2
+		lexer.TokenTypes = []simplexer.TokenType{
+			simplexer.NewRegexpTokenType(0, `^[0-9]+`),
+		}
+
+		var err error
+		for err == nil {
+			_, err = lexer.Scan()
+		}
+
+		e, ok := err.(simplexer.TooManyErrorsError)
+		if !ok {
+			t.Fatalf("excepted TooManyErrorsError but got %#v", err)
+		}
+		if e.Count != 3 {
+			t.Errorf("excepted 3 errors but got %d", e.Count)
+		}
+
+		if _, err2 := lexer.Scan(); err2 != err {
+			t.Errorf("excepted same error %#v but got %#v", err, err2)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package simplexer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// TokenCategory is a category of TokenID, for tools like syntax highlighters.
+type TokenCategory int
+
+// Categories of TokenID.
+const (
+	NoCategory TokenCategory = iota
+	KeywordCategory
+	IdentifierCategory
+	OperatorCategory
+	LiteralCategory
+	CommentCategory
+	LayoutCategory
+	ErrorCategory
+)
+
+var categoryNames = []string{"", "keyword", "identifier", "operator", "literal", "comment", "layout", "error"}
+
+// Convert to readable string.
+func (c TokenCategory) String() string {
+	if c >= 0 && int(c) < len(categoryNames) {
+		return categoryNames[c]
+	}
+	return "unknown(" + strconv.Itoa(int(c)) + ")"
+}
+
+// MarshalText encodes TokenCategory as the name, like "keyword".
+func (c TokenCategory) MarshalText() ([]byte, error) {
+	if c < 0 || int(c) >= len(categoryNames) {
+		return nil, fmt.Errorf("unknown token category %d", int(c))
+	}
+	return []byte(categoryNames[c]), nil
+}
+
+// UnmarshalText decodes the name of TokenCategory.
+func (c *TokenCategory) UnmarshalText(text []byte) error {
+	for i, name := range categoryNames {
+		if name == string(text) {
+			*c = TokenCategory(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown token category %#v", string(text))
+}
+
+type tokenInfo struct {
+	name     string
+	category TokenCategory
+	builtin  bool
+}
+
+/*
+TokenRegistry is a map of TokenID to the name and the category.
+
+TokenID.String uses DefaultRegistry, so registered names are used in Token.String and error messages.
+Please use another TokenRegistry if you don't want to share names between lexers.
+
+Lookups don't take a lock, because registering is rare but TokenID.String is called frequently.
+Register and New copy the maps and replace them.
+*/
+type TokenRegistry struct {
+	mu   sync.Mutex
+	data atomic.Pointer[registryData]
+}
+
+// registryData is an immutable snapshot of TokenRegistry.
+type registryData struct {
+	tokens map[TokenID]tokenInfo
+	ids    map[string]TokenID
+}
+
+// FirstNewTokenID is the TokenID that TokenRegistry.New allocates first. TokenIDs less than it are reserved for user defined constants.
+const FirstNewTokenID TokenID = 1 << 16
+
+// DefaultRegistry is the TokenRegistry that used by TokenID.String.
+var DefaultRegistry = NewTokenRegistry()
+
+// Make a new TokenRegistry that has default token IDs like IDENT.
+func NewTokenRegistry() *TokenRegistry {
+	d := &registryData{
+		tokens: make(map[TokenID]tokenInfo),
+		ids:    make(map[string]TokenID),
+	}
+
+	d.add(OTHER, "OTHER", NoCategory, true)
+	d.add(IDENT, "IDENT", IdentifierCategory, true)
+	d.add(NUMBER, "NUMBER", LiteralCategory, true)
+	d.add(STRING, "STRING", LiteralCategory, true)
+	d.add(ERROR, "ERROR", ErrorCategory, true)
+	d.add(COMMENT, "COMMENT", CommentCategory, true)
+	d.add(INDENT, "INDENT", LayoutCategory, true)
+	d.add(DEDENT, "DEDENT", LayoutCategory, true)
+	d.add(NEWLINE, "NEWLINE", LayoutCategory, true)
+	d.add(EOF, "EOF", LayoutCategory, true)
+
+	r := &TokenRegistry{}
+	r.data.Store(d)
+	return r
+}
+
+func (d *registryData) add(id TokenID, name string, category TokenCategory, builtin bool) {
+	d.tokens[id] = tokenInfo{name: name, category: category, builtin: builtin}
+	d.ids[name] = id
+}
+
+// load returns the current snapshot. The zero value of TokenRegistry is an empty registry.
+func (r *TokenRegistry) load() *registryData {
+	if d := r.data.Load(); d != nil {
+		return d
+	}
+	return &registryData{}
+}
+
+// update adds id to a copy of the current snapshot, and replaces the snapshot. r.mu has to be locked.
+func (r *TokenRegistry) update(id TokenID, name string, category TokenCategory) {
+	old := r.load()
+	d := &registryData{
+		tokens: make(map[TokenID]tokenInfo, len(old.tokens)+1),
+		ids:    make(map[string]TokenID, len(old.ids)+1),
+	}
+	for k, v := range old.tokens {
+		d.tokens[k] = v
+	}
+	for k, v := range old.ids {
+		d.ids[k] = v
+	}
+
+	d.add(id, name, category, old.tokens[id].builtin)
+	r.data.Store(d)
+}
+
+/*
+Register registers the name and the category of id.
+
+Returns an error if id already has another name, or the name is already used by another id.
+Registering the same pair again updates the category.
+
+Names of default token IDs like NEWLINE can be registered for another id, because they are not chosen by users.
+In this case, Lookup returns the new id, and the default token ID keeps the name.
+*/
+func (r *TokenRegistry) Register(id TokenID, name string, category TokenCategory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.load()
+	if info, ok := d.tokens[id]; ok && info.name != name {
+		return fmt.Errorf("token id %d is already registered as %#v", int(id), info.name)
+	}
+	if x, ok := d.ids[name]; ok && x != id && !d.tokens[x].builtin {
+		return fmt.Errorf("token name %#v is already registered as %d", name, int(x))
+	}
+
+	r.update(id, name, category)
+	return nil
+}
+
+/*
+New allocates a new TokenID for the name.
+
+Allocated TokenID is the smallest TokenID from FirstNewTokenID that not registered yet,
+so it never conflicts with default token IDs, registered token IDs, and constants like below.
+
+	const (
+		NUMBER simplexer.TokenID = iota
+		PLUS
+	)
+
+Returns the registered TokenID if the name is already registered.
+*/
+func (r *TokenRegistry) New(name string, category TokenCategory) TokenID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.load()
+	if id, ok := d.ids[name]; ok {
+		return id
+	}
+
+	id := FirstNewTokenID
+	for {
+		if _, ok := d.tokens[id]; !ok {
+			break
+		}
+		id++
+	}
+
+	r.update(id, name, category)
+	return id
+}
+
+// Name returns the name of id.
+func (r *TokenRegistry) Name(id TokenID) (string, bool) {
+	info, ok := r.load().tokens[id]
+	return info.name, ok
+}
+
+// Category returns the category of id. Returns NoCategory if id is not registered.
+func (r *TokenRegistry) Category(id TokenID) TokenCategory {
+	return r.load().tokens[id].category
+}
+
+// Lookup returns TokenID of the name.
+func (r *TokenRegistry) Lookup(name string) (TokenID, bool) {
+	id, ok := r.load().ids[name]
+	return id, ok
+}
+
+// IDs returns all registered TokenIDs in ascending order.
+func (r *TokenRegistry) IDs() []TokenID {
+	tokens := r.load().tokens
+
+	ids := make([]TokenID, 0, len(tokens))
+	for id := range tokens {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// String returns the name of id, or UNKNOWN(id) if id is not registered.
+func (r *TokenRegistry) String(id TokenID) string {
+	if name, ok := r.Name(id); ok {
+		return name
+	}
+	return "UNKNOWN(" + strconv.Itoa(int(id)) + ")"
+}
+
+// TokenString returns readable string of Token like Token.String, but uses the name in this registry.
+func (r *TokenRegistry) TokenString(t *Token) string {
+	return fmt.Sprintf("%s(%#v)", r.String(t.Type.GetID()), t.Literal)
+}
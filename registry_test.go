@@ -0,0 +1,259 @@
+package simplexer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleTokenRegistry() {
+	registry := simplexer.NewTokenRegistry()
+
+	ASSIGN := registry.New("ASSIGN", simplexer.OperatorCategory)
+	LET := registry.New("LET", simplexer.KeywordCategory)
+
+	lexer := simplexer.NewLexer(strings.NewReader("let x = 1"))
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewPatternTokenType(ASSIGN, []string{"="}),
+		simplexer.NewKeywordTokenType(map[string]simplexer.TokenID{"let": LET}, false),
+	}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%s %s\n", registry.TokenString(token), registry.Category(token.Type.GetID()))
+	}
+
+	// Output:
+	// LET("let") keyword
+	// IDENT("x") identifier
+	// ASSIGN("=") operator
+	// NUMBER("1") literal
+}
+
+func TestTokenRegistry(t *testing.T) {
+	registry := simplexer.NewTokenRegistry()
+
+	if err := registry.Register(0, "ZERO", simplexer.LiteralCategory); err != nil {
+		t.Errorf("failed to register: %s", err)
+	}
+	if err := registry.Register(2, "TWO", simplexer.NoCategory); err != nil {
+		t.Errorf("failed to register: %s", err)
+	}
+	if err := registry.Register(2, "TWO", simplexer.OperatorCategory); err != nil {
+		t.Errorf("failed to register the same pair again: %s", err)
+	}
+
+	if err := registry.Register(0, "ANOTHER", simplexer.NoCategory); err == nil || err.Error() != `token id 0 is already registered as "ZERO"` {
+		t.Errorf("excepted conflict of id but got %v", err)
+	}
+	if err := registry.Register(3, "TWO", simplexer.NoCategory); err == nil || err.Error() != `token name "TWO" is already registered as 2` {
+		t.Errorf("excepted conflict of name but got %v", err)
+	}
+
+	if err := registry.Register(3, "NEWLINE", simplexer.NoCategory); err != nil {
+		t.Errorf("failed to register the name of default token id: %s", err)
+	}
+	if id, ok := registry.Lookup("NEWLINE"); !ok || id != 3 {
+		t.Errorf("excepted 3 but got %d, %v", id, ok)
+	}
+	if s := registry.String(simplexer.NEWLINE); s != "NEWLINE" {
+		t.Errorf("excepted default token id keeps the name but got %s", s)
+	}
+	if err := registry.Register(4, "NEWLINE", simplexer.NoCategory); err == nil || err.Error() != `token name "NEWLINE" is already registered as 3` {
+		t.Errorf("excepted conflict of name but got %v", err)
+	}
+
+	for i, except := range []simplexer.TokenID{simplexer.FirstNewTokenID, simplexer.FirstNewTokenID + 1, simplexer.FirstNewTokenID + 2} {
+		if id := registry.New(fmt.Sprintf("NEW%d", i), simplexer.NoCategory); id != except {
+			t.Errorf("excepted %d but got %d", except, id)
+		}
+	}
+	if id := registry.New("TWO", simplexer.NoCategory); id != 2 {
+		t.Errorf("excepted registered id 2 but got %d", id)
+	}
+
+	if id, ok := registry.Lookup("NEW1"); !ok || id != simplexer.FirstNewTokenID+1 {
+		t.Errorf("excepted %d but got %d, %v", simplexer.FirstNewTokenID+1, id, ok)
+	}
+	if _, ok := registry.Lookup("NOTHING"); ok {
+		t.Errorf("excepted not found")
+	}
+
+	if c := registry.Category(2); c != simplexer.OperatorCategory {
+		t.Errorf("excepted operator but got %s", c)
+	}
+	if c := registry.Category(simplexer.COMMENT); c != simplexer.CommentCategory {
+		t.Errorf("excepted comment but got %s", c)
+	}
+
+	if err := registry.Register(1, "ONE", simplexer.NoCategory); err != nil {
+		t.Errorf("failed to register user defined id after New: %s", err)
+	}
+
+	if s := registry.String(simplexer.FirstNewTokenID + 2); s != "NEW2" {
+		t.Errorf("excepted NEW2 but got %s", s)
+	}
+	if s := registry.String(100); s != "UNKNOWN(100)" {
+		t.Errorf("excepted UNKNOWN(100) but got %s", s)
+	}
+
+	ids := registry.IDs()
+	if len(ids) != 17 || ids[0] != simplexer.EOF || ids[len(ids)-1] != simplexer.FirstNewTokenID+2 {
+		t.Errorf("excepted sorted 17 ids but got %v", ids)
+	}
+}
+
+func TestTokenRegistry_concurrent(t *testing.T) {
+	registry := simplexer.NewTokenRegistry()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			registry.New(fmt.Sprintf("TOKEN%d", i), simplexer.NoCategory)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if s := registry.String(simplexer.IDENT); s != "IDENT" {
+			t.Fatalf("excepted IDENT but got %s", s)
+		}
+	}
+	<-done
+
+	if s := registry.String(simplexer.FirstNewTokenID + 99); s != "TOKEN99" {
+		t.Errorf("excepted TOKEN99 but got %s", s)
+	}
+}
+
+func TestTokenID_String_registered(t *testing.T) {
+	const id simplexer.TokenID = 987654
+
+	if s := id.String(); s != "UNKNOWN(987654)" {
+		t.Errorf("excepted UNKNOWN(987654) but got %s", s)
+	}
+
+	if err := simplexer.DefaultRegistry.Register(id, "TEST_REGISTERED", simplexer.NoCategory); err != nil {
+		t.Fatalf("failed to register: %s", err)
+	}
+
+	if s := id.String(); s != "TEST_REGISTERED" {
+		t.Errorf("excepted TEST_REGISTERED but got %s", s)
+	}
+
+	tok := &simplexer.Token{Type: simplexer.NewPatternTokenType(id, nil), Literal: "x"}
+	if s := tok.String(); s != `TEST_REGISTERED("x")` {
+		t.Errorf("excepted TEST_REGISTERED(\"x\") but got %s", s)
+	}
+}
+
+func TestTokenCategory_text(t *testing.T) {
+	data, err := json.Marshal([]simplexer.TokenCategory{simplexer.KeywordCategory, simplexer.NoCategory})
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	if string(data) != `["keyword",""]` {
+		t.Errorf("excepted [\"keyword\",\"\"] but got %s", data)
+	}
+
+	var c simplexer.TokenCategory
+	if err := json.Unmarshal([]byte(`"comment"`), &c); err != nil || c != simplexer.CommentCategory {
+		t.Errorf("excepted comment but got %s, %v", c, err)
+	}
+	if err := json.Unmarshal([]byte(`"unknown"`), &c); err == nil {
+		t.Errorf("excepted error but got nil")
+	}
+
+	if s := simplexer.TokenCategory(100).String(); s != "unknown(100)" {
+		t.Errorf("excepted unknown(100) but got %s", s)
+	}
+}
+
+func TestUnexpectedTokenError(t *testing.T) {
+	registry := simplexer.NewTokenRegistry()
+	SEMICOLON := registry.New("SEMICOLON", simplexer.OperatorCategory)
+
+	err := simplexer.UnexpectedTokenError{
+		Token: &simplexer.Token{
+			Type:     simplexer.NewPatternTokenType(simplexer.IDENT, nil),
+			Literal:  "x",
+			Position: simplexer.Position{Line: 1, Column: 2},
+		},
+		Expected: []simplexer.TokenID{simplexer.NUMBER, simplexer.STRING, SEMICOLON},
+	}
+
+	except := fmt.Sprintf(`2:3:UnexpectedTokenError: unexpected IDENT "x", expected NUMBER, STRING or %s`, SEMICOLON)
+	if err.Error() != except {
+		t.Errorf("excepted %#v but got %#v", except, err.Error())
+	}
+
+	r := simplexer.DiagnosticRenderer{Registry: registry}
+	except = `2:3: error: unexpected IDENT "x", expected NUMBER, STRING or SEMICOLON`
+	if s := r.RenderPlain(err); s != except {
+		t.Errorf("excepted %#v but got %#v", except, s)
+	}
+
+	err.Expected = nil
+	except = `2:3: error: unexpected IDENT "x"`
+	if s := r.RenderPlain(err); s != except {
+		t.Errorf("excepted %#v but got %#v", except, s)
+	}
+}
+
+func TestSpec_Registry(t *testing.T) {
+	spec, err := simplexer.ParseSpec([]byte(`{"tokens": [
+		{"name": "IF", "id": 1, "category": "keyword", "patterns": ["if"]},
+		{"name": "IDENT", "id": -2, "regexp": "[a-z]+"},
+		{"id": 2, "regexp": "[0-9]+"}
+	]}`))
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+
+	registry, err := spec.Registry()
+	if err != nil {
+		t.Fatalf("failed to make registry: %s", err)
+	}
+
+	if s := registry.String(1); s != "IF" {
+		t.Errorf("excepted IF but got %s", s)
+	}
+	if c := registry.Category(1); c != simplexer.KeywordCategory {
+		t.Errorf("excepted keyword but got %s", c)
+	}
+	if s := registry.String(2); s != "UNKNOWN(2)" {
+		t.Errorf("excepted UNKNOWN(2) but got %s", s)
+	}
+
+	spec.Tokens[0].Name = "NUMBER"
+	if registry, err := spec.Registry(); err != nil {
+		t.Errorf("failed to use the name of default token id: %s", err)
+	} else if id, _ := registry.Lookup("NUMBER"); id != 1 {
+		t.Errorf("excepted NUMBER is 1 but got %d", id)
+	}
+
+	spec.Tokens[2].Name = "NUMBER"
+	if _, err := spec.Registry(); err == nil || err.Error() != `tokens[2]:SpecError: token name "NUMBER" is already registered as 1` {
+		t.Errorf("excepted conflict error but got %v", err)
+	}
+
+	_, err = simplexer.ParseSpec([]byte(`{"tokens": [{"name": "A", "id": 1, "regexp": "a"}, {"name": "B", "id": 1, "regexp": "b"}]}`))
+	if err == nil || err.Error() != `1:61:SpecError: tokens[1].name: id 1 is already named "A"` {
+		t.Errorf("excepted name conflict error but got %v", err)
+	}
+
+	_, err = simplexer.ParseSpec([]byte(`{"tokens": [{"id": 1, "category": "nothing", "regexp": "a"}]}`))
+	if err == nil || err.Error() != `1:23:SpecError: tokens[0].category: unknown token category "nothing"` {
+		t.Errorf("excepted unknown category error but got %v", err)
+	}
+}
@@ -0,0 +1,629 @@
+package simplexer
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Spec is a declarative specification of Lexer, that can be written in JSON, YAML or TOML.
+
+	{
+		"whitespace": {"regexp": "[ \t\n]+"},
+		"tokens": [
+			{"name": "NUMBER", "id": 1, "regexp": "[0-9]+"},
+			{"name": "OPERATOR", "id": 2, "patterns": ["+", "-"]},
+			{"name": "QUOTE", "id": 3, "patterns": ["\""], "push": "string"}
+		],
+		"states": {
+			"string": {
+				"whitespace": {},
+				"tokens": [
+					{"name": "QUOTE", "id": 3, "patterns": ["\""], "pop": true},
+					{"name": "TEXT", "id": 4, "regexp": "[^\"]+"}
+				]
+			}
+		}
+	}
+
+Whitespace is the rule of Lexer.Whitespace. DefaultWhitespace is used if it is nil.
+A rule without regexp and patterns means no whitespace.
+
+Strategy is "first" for FirstMatch, or "longest" for LongestMatch. FirstMatch is used if it is empty.
+
+Tokens are rules of Lexer.TokenTypes. States are rules of Lexer.States.
+
+Fields have json, yaml and toml struct tags, so the keys are the same lower case words in every format.
+*/
+type Spec struct {
+	Whitespace *RuleSpec            `json:"whitespace,omitempty" yaml:"whitespace,omitempty" toml:"whitespace,omitempty"`
+	Strategy   string               `json:"strategy,omitempty" yaml:"strategy,omitempty" toml:"strategy,omitempty"`
+	Tokens     []RuleSpec           `json:"tokens" yaml:"tokens" toml:"tokens"`
+	States     map[string]StateSpec `json:"states,omitempty" yaml:"states,omitempty" toml:"states,omitempty"`
+}
+
+/*
+RuleSpec is a rule of token in Spec.
+
+Name is the name of token. It is optional, but the same name has to have the same ID.
+
+ID is TokenID of the token.
+
+Category is TokenCategory of the token, like "keyword". Spec.Registry uses Name and Category.
+
+Regexp is a regular expression like RegexpTokenType, and Patterns is an array of strings like PatternTokenType.
+One of them is required.
+
+Priority is the priority of the rule. Rules are checked from higher priority, and in order of Spec for the same priority.
+
+Push, Pop and Switch change the state of Lexer like StateTokenType. Only one of them can be set.
+*/
+type RuleSpec struct {
+	Name     string        `json:"name,omitempty" yaml:"name,omitempty" toml:"name,omitempty"`
+	ID       TokenID       `json:"id" yaml:"id" toml:"id"`
+	Category TokenCategory `json:"category,omitempty" yaml:"category,omitempty" toml:"category,omitempty"`
+	Regexp   string        `json:"regexp,omitempty" yaml:"regexp,omitempty" toml:"regexp,omitempty"`
+	Patterns []string      `json:"patterns,omitempty" yaml:"patterns,omitempty" toml:"patterns,omitempty"`
+	Priority int           `json:"priority,omitempty" yaml:"priority,omitempty" toml:"priority,omitempty"`
+	Push     string        `json:"push,omitempty" yaml:"push,omitempty" toml:"push,omitempty"`
+	Pop      bool          `json:"pop,omitempty" yaml:"pop,omitempty" toml:"pop,omitempty"`
+	Switch   string        `json:"switch,omitempty" yaml:"switch,omitempty" toml:"switch,omitempty"`
+}
+
+// StateSpec is a state in Spec. Whitespace and Tokens are the same as Spec.
+type StateSpec struct {
+	Whitespace *RuleSpec  `json:"whitespace,omitempty" yaml:"whitespace,omitempty" toml:"whitespace,omitempty"`
+	Tokens     []RuleSpec `json:"tokens" yaml:"tokens" toml:"tokens"`
+}
+
+// UnmarshalFunc is a function to decode Spec, like json.Unmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// MarshalFunc is a function to encode Spec, like json.Marshal.
+type MarshalFunc func(v interface{}) ([]byte, error)
+
+/*
+ParseSpec decodes and validates JSON of Spec.
+
+Returns SpecError if failed.
+Position of SpecError is always set, and Path of SpecError is set if the Spec is invalid.
+If the Spec is invalid, Position is the position of the value at Path, or of the nearest parent in the JSON.
+For unknown keys and unknown categories, Position is the position of the key.
+*/
+func ParseSpec(data []byte) (*Spec, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, jsonSpecError(data, err)
+	}
+	if err := s.Validate(); err != nil {
+		if se, ok := err.(SpecError); ok {
+			se.Position = jsonPathPosition(data, se.Path)
+			se.HasPosition = true
+			return nil, se
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+/*
+ParseSpecWith decodes and validates Spec by unmarshal function.
+
+unmarshal is a function of YAML or TOML library, like yaml.Unmarshal.
+Errors from unmarshal are returned as SpecError.
+*/
+func ParseSpecWith(data []byte, unmarshal UnmarshalFunc) (*Spec, error) {
+	var s Spec
+	if err := unmarshal(data, &s); err != nil {
+		return nil, SpecError{Message: err.Error()}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func jsonSpecError(data []byte, err error) error {
+	var (
+		path    string
+		message = err.Error()
+		offset  int
+	)
+
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		// Offset is after the invalid character.
+		offset = int(e.Offset) - 1
+	case *json.UnmarshalTypeError:
+		// Offset is after the invalid value, so use the position of the path like Validate.
+		path = jsonFieldPath(e.Field)
+		return SpecError{
+			Path:        path,
+			Message:     fmt.Sprintf("cannot unmarshal %s into %s", e.Value, e.Type),
+			Position:    jsonPathPosition(data, path),
+			HasPosition: true,
+		}
+	default:
+		if err == io.ErrUnexpectedEOF {
+			offset = len(data)
+			break
+		}
+
+		// Errors of unknown fields and UnmarshalText have no offset, so look for the key in JSON.
+		var ok bool
+		if path, message, offset, ok = jsonInvalidKey(data, reflect.TypeOf(Spec{})); !ok {
+			return SpecError{Message: err.Error()}
+		}
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return SpecError{
+		Path:        path,
+		Message:     message,
+		Position:    shiftPos(Position{}, string(data[:offset])),
+		HasPosition: true,
+	}
+}
+
+// jsonFieldPath converts the path of json.UnmarshalTypeError like "tokens.0.id" into the path like "tokens[0].id".
+func jsonFieldPath(field string) string {
+	path := ""
+	for i, name := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(name); err == nil && i > 0 {
+			path += "[" + name + "]"
+		} else if path == "" {
+			path = name
+		} else {
+			path += "." + name
+		}
+	}
+	return path
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+/*
+jsonInvalidKey finds the first key in JSON that can not be decoded into typ.
+The key is not a field of typ, or UnmarshalText fails for the value of the key.
+
+Returns the path, the error message, and the offset of the key.
+*/
+func jsonInvalidKey(data []byte, typ reflect.Type) (path, message string, offset int, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(p string, key int, typ reflect.Type) (bool, error)
+	walk = func(p string, key int, typ reflect.Type) (bool, error) {
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key := skipJSONSeparators(data, int(dec.InputOffset()))
+				k, err := dec.Token()
+				if err != nil {
+					return false, err
+				}
+
+				name := k.(string)
+				if p != "" {
+					name = p + "." + name
+				}
+
+				var elem reflect.Type
+				switch {
+				case typ == nil:
+				case typ.Kind() == reflect.Map:
+					elem = typ.Elem()
+				case typ.Kind() == reflect.Struct:
+					if elem = jsonFieldType(typ, k.(string)); elem == nil {
+						path, message, offset = name, "unknown field", key
+						return true, nil
+					}
+				}
+
+				if found, err := walk(name, key, elem); found || err != nil {
+					return found, err
+				}
+			}
+		case json.Delim('['):
+			var elem reflect.Type
+			if typ != nil && typ.Kind() == reflect.Slice {
+				elem = typ.Elem()
+			}
+
+			for i := 0; dec.More(); i++ {
+				key := skipJSONSeparators(data, int(dec.InputOffset()))
+				if found, err := walk(fmt.Sprintf("%s[%d]", p, i), key, elem); found || err != nil {
+					return found, err
+				}
+			}
+		default:
+			if s, isString := tok.(string); isString && typ != nil && reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+				if err := reflect.New(typ).Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+					path, message, offset = p, err.Error(), key
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+
+		// Closing delimiter.
+		_, err = dec.Token()
+		return false, err
+	}
+
+	found, _ := walk("", 0, typ)
+	return path, message, offset, found
+}
+
+// jsonFieldType returns the type of the field of struct typ for the JSON key, or nil if there is no such field.
+func jsonFieldType(typ reflect.Type, key string) reflect.Type {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if name != "-" && strings.EqualFold(name, key) {
+			return f.Type
+		}
+	}
+	return nil
+}
+
+// jsonPathPosition returns the position of the value at path in JSON, or of the nearest parent if path is not in JSON.
+func jsonPathPosition(data []byte, path string) Position {
+	offsets := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		offsets[path] = skipJSONSeparators(data, int(dec.InputOffset()))
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+
+				name := key.(string)
+				if path != "" {
+					name = path + "." + name
+				}
+				if err := walk(name); err != nil {
+					return err
+				}
+			}
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		default:
+			return nil
+		}
+
+		// Closing delimiter.
+		_, err = dec.Token()
+		return err
+	}
+	walk("")
+
+	for {
+		if offset, ok := offsets[path]; ok {
+			return shiftPos(Position{}, string(data[:offset]))
+		}
+		if path == "" {
+			return Position{}
+		}
+
+		idx := strings.LastIndexAny(path, ".[")
+		if idx < 0 {
+			idx = 0
+		}
+		path = path[:idx]
+	}
+}
+
+// skipJSONSeparators returns the offset of the next value after whitespaces, colons and commas.
+func skipJSONSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n:,", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// Marshal encodes Spec into JSON.
+func (s *Spec) Marshal() ([]byte, error) {
+	return json.MarshalIndent(s, "", "\t")
+}
+
+// MarshalWith encodes Spec by marshal function, like yaml.Marshal.
+func (s *Spec) MarshalWith(marshal MarshalFunc) ([]byte, error) {
+	return marshal(s)
+}
+
+/*
+Validate checks Spec, and returns SpecError with Path if Spec is invalid.
+
+Path is like "states.string.tokens[1].regexp".
+Rules that can match the empty string are invalid, because Lexer can't advance with them.
+*/
+func (s *Spec) Validate() error {
+	switch s.Strategy {
+	case "", "first", "longest":
+	default:
+		return SpecError{Path: "strategy", Message: fmt.Sprintf("unknown strategy %#v", s.Strategy)}
+	}
+
+	names := make(map[string]TokenID)
+	ids := make(map[TokenID]string)
+
+	if err := s.validateRules("", s.Whitespace, s.Tokens, names, ids); err != nil {
+		return err
+	}
+
+	stateNames := make([]string, 0, len(s.States))
+	for name := range s.States {
+		stateNames = append(stateNames, name)
+	}
+	sort.Strings(stateNames)
+
+	for _, name := range stateNames {
+		path := "states." + name
+		if name == "" || name == DefaultState {
+			return SpecError{Path: path, Message: fmt.Sprintf("invalid state name %#v", name)}
+		}
+
+		state := s.States[name]
+		if err := s.validateRules(path+".", state.Whitespace, state.Tokens, names, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Spec) validateRules(prefix string, whitespace *RuleSpec, tokens []RuleSpec, names map[string]TokenID, ids map[TokenID]string) error {
+	if whitespace != nil {
+		if err := s.validateRule(prefix+"whitespace", whitespace, names, ids, true); err != nil {
+			return err
+		}
+		if whitespace.Push != "" || whitespace.Pop || whitespace.Switch != "" {
+			return SpecError{Path: prefix + "whitespace", Message: "whitespace can not change state"}
+		}
+	}
+
+	if len(tokens) == 0 {
+		return SpecError{Path: prefix + "tokens", Message: "no tokens"}
+	}
+
+	for i := range tokens {
+		if err := s.validateRule(fmt.Sprintf("%stokens[%d]", prefix, i), &tokens[i], names, ids, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Spec) validateRule(path string, r *RuleSpec, names map[string]TokenID, ids map[TokenID]string, optional bool) error {
+	if r.Name != "" {
+		if id, ok := names[r.Name]; ok && id != r.ID {
+			return SpecError{Path: path + ".id", Message: fmt.Sprintf("name %#v is already used for id %d", r.Name, id)}
+		}
+		if name, ok := ids[r.ID]; ok && name != r.Name {
+			return SpecError{Path: path + ".name", Message: fmt.Sprintf("id %d is already named %#v", r.ID, name)}
+		}
+		names[r.Name] = r.ID
+		ids[r.ID] = r.Name
+	}
+
+	switch {
+	case r.Regexp != "" && len(r.Patterns) > 0:
+		return SpecError{Path: path, Message: "regexp and patterns can not be used together"}
+	case r.Regexp != "":
+		if _, err := regexp.Compile(anchorRegexp(r.Regexp)); err != nil {
+			return SpecError{Path: path + ".regexp", Message: err.Error()}
+		}
+		if matchesEmpty(r.Regexp) {
+			return SpecError{Path: path, Message: "matches the empty string"}
+		}
+	case len(r.Patterns) > 0:
+		for i, x := range r.Patterns {
+			if x == "" {
+				return SpecError{Path: fmt.Sprintf("%s.patterns[%d]", path, i), Message: "empty pattern"}
+			}
+		}
+	case !optional:
+		return SpecError{Path: path, Message: "regexp or patterns is required"}
+	}
+
+	actions := 0
+	for _, state := range []string{r.Push, r.Switch} {
+		if state == "" {
+			continue
+		}
+		actions++
+
+		if _, ok := s.States[state]; !ok && state != DefaultState {
+			return SpecError{Path: path, Message: fmt.Sprintf("unknown state %#v", state)}
+		}
+	}
+	if r.Pop {
+		actions++
+	}
+	if actions > 1 {
+		return SpecError{Path: path, Message: "only one of push, pop and switch can be set"}
+	}
+
+	return nil
+}
+
+/*
+Registry makes a new TokenRegistry that has names and categories of rules in Spec.
+
+Returns SpecError if a name conflicts with default token IDs.
+*/
+func (s *Spec) Registry() (*TokenRegistry, error) {
+	r := NewTokenRegistry()
+
+	register := func(path string, rules []RuleSpec) error {
+		for i, rule := range rules {
+			if rule.Name == "" {
+				continue
+			}
+			if err := r.Register(rule.ID, rule.Name, rule.Category); err != nil {
+				return SpecError{Path: fmt.Sprintf("%stokens[%d]", path, i), Message: err.Error()}
+			}
+		}
+		return nil
+	}
+
+	if err := register("", s.Tokens); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(s.States))
+	for name := range s.States {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := register("states."+name+".", s.States[name].Tokens); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+/*
+matchesEmpty reports whether re can match the empty string.
+
+Lexer can't advance with such a rule, so rules like "[0-9]*" are invalid.
+Rules that match only an empty-width assertion like "\\b" are invalid too.
+*/
+func matchesEmpty(re string) bool {
+	parsed, err := syntax.Parse(anchorRegexp(re), syntax.Perl)
+	if err != nil {
+		return false
+	}
+	return new(byteSet).addFirst(parsed)
+}
+
+// anchorRegexp makes re to match only the head of string.
+func anchorRegexp(re string) string {
+	if !strings.HasPrefix(re, "^") {
+		return "^(?:" + re + ")"
+	}
+	return re
+}
+
+func (r *RuleSpec) tokenType() TokenType {
+	var tt TokenType
+	if r.Regexp != "" {
+		tt = NewRegexpTokenType(r.ID, r.Regexp)
+	} else {
+		tt = NewPatternTokenType(r.ID, r.Patterns)
+	}
+
+	switch {
+	case r.Push != "":
+		return NewStateTokenType(tt, StatePush, r.Push)
+	case r.Pop:
+		return NewStateTokenType(tt, StatePop, "")
+	case r.Switch != "":
+		return NewStateTokenType(tt, StateSwitch, r.Switch)
+	}
+	return tt
+}
+
+func whitespaceType(r *RuleSpec) TokenType {
+	switch {
+	case r == nil:
+		return DefaultWhitespace
+	case r.Regexp == "" && len(r.Patterns) == 0:
+		return nil
+	default:
+		return r.tokenType()
+	}
+}
+
+func tokenTypes(rules []RuleSpec) []TokenType {
+	sorted := append([]RuleSpec(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+
+	types := make([]TokenType, len(sorted))
+	for i := range sorted {
+		types[i] = sorted[i].tokenType()
+	}
+	return types
+}
+
+/*
+NewLexer makes a new Lexer that configured by Spec.
+
+Returns SpecError if Spec is invalid.
+*/
+func (s *Spec) NewLexer(reader io.Reader) (*Lexer, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	l := NewLexer(reader)
+	l.Whitespace = whitespaceType(s.Whitespace)
+	l.TokenTypes = tokenTypes(s.Tokens)
+
+	if s.Strategy == "longest" {
+		l.Strategy = LongestMatch
+	}
+
+	if len(s.States) > 0 {
+		l.States = make(map[string]*State)
+		for name, state := range s.States {
+			l.States[name] = &State{
+				Whitespace: whitespaceType(state.Whitespace),
+				TokenTypes: tokenTypes(state.Tokens),
+			}
+		}
+	}
+
+	return l, nil
+}
@@ -0,0 +1,193 @@
+package simplexer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+const testSpec = `{
+	"whitespace": {"regexp": "[ \t\n]+"},
+	"tokens": [
+		{"name": "IDENT", "id": 1, "regexp": "[a-z]+"},
+		{"name": "KEYWORD", "id": 2, "patterns": ["if", "else"], "priority": 1},
+		{"name": "QUOTE", "id": 3, "patterns": ["\""], "push": "string"}
+	],
+	"states": {
+		"string": {
+			"whitespace": {},
+			"tokens": [
+				{"name": "QUOTE", "id": 3, "patterns": ["\""], "pop": true},
+				{"name": "TEXT", "id": 4, "regexp": "[^\"]+"}
+			]
+		}
+	}
+}`
+
+func ExampleParseSpec() {
+	spec, err := simplexer.ParseSpec([]byte(testSpec))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	lexer, err := spec.NewLexer(strings.NewReader(`if x " hello world " else`))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%d: %#v\n", token.Type.GetID(), token.Literal)
+	}
+
+	// Output:
+	// 2: "if"
+	// 1: "x"
+	// 3: "\""
+	// 4: " hello world "
+	// 3: "\""
+	// 2: "else"
+}
+
+func TestSpec_roundTrip(t *testing.T) {
+	spec, err := simplexer.ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+
+	data, err := spec.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+
+	again, err := simplexer.ParseSpec(data)
+	if err != nil {
+		t.Fatalf("failed to parse marshaled spec: %s\n%s", err, data)
+	}
+	if !reflect.DeepEqual(spec, again) {
+		t.Errorf("excepted the same spec but got different one\n%s", data)
+	}
+
+	data, err = spec.MarshalWith(json.Marshal)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+
+	again, err = simplexer.ParseSpecWith(data, json.Unmarshal)
+	if err != nil {
+		t.Fatalf("failed to parse marshaled spec: %s\n%s", err, data)
+	}
+	if !reflect.DeepEqual(spec, again) {
+		t.Errorf("excepted the same spec but got different one\n%s", data)
+	}
+}
+
+func TestSpec_defaults(t *testing.T) {
+	spec := &simplexer.Spec{
+		Strategy: "longest",
+		Tokens: []simplexer.RuleSpec{
+			{ID: 1, Patterns: []string{"a"}},
+		},
+	}
+
+	lexer, err := spec.NewLexer(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("failed to make lexer: %s", err)
+	}
+
+	if lexer.Whitespace != simplexer.DefaultWhitespace {
+		t.Errorf("excepted default whitespace but got %v", lexer.Whitespace)
+	}
+	if lexer.Strategy != simplexer.LongestMatch {
+		t.Errorf("excepted LongestMatch but got %v", lexer.Strategy)
+	}
+	if lexer.States != nil {
+		t.Errorf("excepted no states but got %v", lexer.States)
+	}
+
+	spec.Whitespace = &simplexer.RuleSpec{}
+	lexer, err = spec.NewLexer(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("failed to make lexer: %s", err)
+	}
+	if lexer.Whitespace != nil {
+		t.Errorf("excepted no whitespace but got %v", lexer.Whitespace)
+	}
+}
+
+func TestParseSpec_errors(t *testing.T) {
+	tests := []struct {
+		Input string
+		Error string
+	}{
+		{`{"tokens": [}`, "1:13:SpecError: invalid character '}' looking for beginning of value"},
+		{`{"tokens": [], "unknown": 1}`, "1:16:SpecError: unknown: unknown field"},
+		{"{\n  \"tokens\": [\n    {\"id\": 1, \"regexp\": \"a\", \"Push\": \"x\", \"pushed\": \"x\"}\n  ]\n}", "3:43:SpecError: tokens[0].pushed: unknown field"},
+		{`{"tokens": [{"id": 1, "regexp": "a"}], "states": {"x": {"tokens": [], "pop": true}}}`, "1:71:SpecError: states.x.pop: unknown field"},
+		{`{"tokens": [{"id": 1, "category": "nothing", "regexp": "a"}]}`, `1:23:SpecError: tokens[0].category: unknown token category "nothing"`},
+		{`{"tokens": [{"id": "x", "regexp": "a"}]}`, "1:20:SpecError: tokens[0].id: cannot unmarshal string into simplexer.TokenID"},
+		{`{"tokens": [{"id": 1, "regexp": "a"}], "states": {"s": {"tokens": [{"id": true}]}}}`, "1:75:SpecError: states.s.tokens[0].id: cannot unmarshal bool into simplexer.TokenID"},
+		{`[`, "1:2:SpecError: unexpected EOF"},
+		{`{"tokens": []}`, "1:12:SpecError: tokens: no tokens"},
+		{`{"strategy": "shortest", "tokens": [{"id": 1, "regexp": "a"}]}`, `1:14:SpecError: strategy: unknown strategy "shortest"`},
+		{`{"tokens": [{"id": 1}]}`, "1:13:SpecError: tokens[0]: regexp or patterns is required"},
+		{`{"tokens": [{"id": 1, "regexp": "a", "patterns": ["b"]}]}`, "1:13:SpecError: tokens[0]: regexp and patterns can not be used together"},
+		{`{"tokens": [{"id": 1, "regexp": "a"}, {"id": 2, "regexp": "("}]}`, "1:59:SpecError: tokens[1].regexp: error parsing regexp: missing closing ): `^(?:()`"},
+		{`{"tokens": [{"id": 1, "patterns": ["a", ""]}]}`, "1:41:SpecError: tokens[0].patterns[1]: empty pattern"},
+		{`{"tokens": [{"name": "A", "id": 1, "regexp": "a"}, {"name": "A", "id": 2, "regexp": "b"}]}`, `1:72:SpecError: tokens[1].id: name "A" is already used for id 1`},
+		{`{"tokens": [{"id": 1, "regexp": "a", "push": "x"}]}`, `1:13:SpecError: tokens[0]: unknown state "x"`},
+		{`{"tokens": [{"id": 1, "regexp": "a", "push": "x", "pop": true}], "states": {"x": {"tokens": [{"id": 1, "regexp": "a"}]}}}`, "1:13:SpecError: tokens[0]: only one of push, pop and switch can be set"},
+		{`{"whitespace": {"regexp": " ", "pop": true}, "tokens": [{"id": 1, "regexp": "a"}]}`, "1:16:SpecError: whitespace: whitespace can not change state"},
+		{`{"tokens": [{"id": 1, "regexp": "a"}], "states": {"x": {"tokens": [{"id": 1, "regexp": "a", "switch": "y"}]}}}`, `1:68:SpecError: states.x.tokens[0]: unknown state "y"`},
+		{`{"tokens": [{"name": "N", "id": 1, "regexp": "[0-9]*"}, {"name": "X", "id": 2, "regexp": "."}]}`, "1:13:SpecError: tokens[0]: matches the empty string"},
+		{`{"whitespace": {"regexp": "\\b"}, "tokens": [{"id": 1, "regexp": "a"}]}`, "1:16:SpecError: whitespace: matches the empty string"},
+		{"{\n  \"tokens\": [\n    {\"id\": 1, \"regexp\": \"a|\"}\n  ]\n}", "3:5:SpecError: tokens[0]: matches the empty string"},
+		{`{"tokens": [{"id": 1, "regexp": "a"}], "states": {"INITIAL": {"tokens": [{"id": 1, "regexp": "a"}]}}}`, `1:62:SpecError: states.INITIAL: invalid state name "INITIAL"`},
+	}
+
+	for _, tc := range tests {
+		_, err := simplexer.ParseSpec([]byte(tc.Input))
+		if err == nil {
+			t.Errorf("%s: excepted error but got nil", tc.Input)
+			continue
+		}
+
+		if _, ok := err.(simplexer.SpecError); !ok {
+			t.Errorf("%s: excepted SpecError but got %T", tc.Input, err)
+		}
+		if err.Error() != tc.Error {
+			t.Errorf("%s: excepted %#v but got %#v", tc.Input, tc.Error, err.Error())
+		}
+	}
+
+	_, err := simplexer.ParseSpec([]byte("{\n  \"tokens\": [\n    {\"id\": \"x\"}\n  ]\n}"))
+	if se, ok := err.(simplexer.SpecError); !ok || se.Path != "tokens[0].id" || se.Position.Line != 2 || !se.HasPosition {
+		t.Errorf("excepted SpecError at tokens[0].id in line 3 but got %#v", err)
+	}
+
+	_, err = simplexer.ParseSpec([]byte("} "))
+	if se, ok := err.(simplexer.SpecError); !ok || se.Position != (simplexer.Position{}) || !se.HasPosition {
+		t.Errorf("excepted SpecError at the head but got %#v", err)
+	} else if se.Error() != "1:1:SpecError: invalid character '}' looking for beginning of value" {
+		t.Errorf("excepted error with position 1:1 but got %#v", se.Error())
+	}
+
+	_, err = simplexer.ParseSpecWith([]byte("x"), func([]byte, interface{}) error {
+		return errors.New("broken")
+	})
+	if err == nil || err.Error() != "SpecError: broken" {
+		t.Errorf("excepted SpecError but got %v", err)
+	}
+}
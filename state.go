@@ -0,0 +1,220 @@
+package simplexer
+
+// DefaultState is the name of the initial state of Lexer.
+const DefaultState = "INITIAL"
+
+/*
+State is a set of rules that used while Lexer is in the state.
+
+Whitespace and TokenTypes have the same meaning as Lexer.Whitespace and Lexer.TokenTypes.
+*/
+type State struct {
+	Whitespace TokenType
+	TokenTypes []TokenType
+}
+
+// StateAction is an action to change state of Lexer.
+type StateAction int
+
+// Actions for StateTokenType.
+const (
+	// StatePush pushes new state into the state stack.
+	StatePush StateAction = iota + 1
+
+	// StatePop pops the current state from the state stack.
+	StatePop
+
+	// StateSwitch replaces the current state with new state.
+	StateSwitch
+)
+
+// Convert to readable string.
+func (a StateAction) String() string {
+	switch a {
+	case StatePush:
+		return "PUSH"
+	case StatePop:
+		return "POP"
+	case StateSwitch:
+		return "SWITCH"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+/*
+StateTokenType is a TokenType that changes state of Lexer when it was scanned.
+
+TokenType is the TokenType for finding a token.
+Found tokens are the same as tokens of TokenType.
+
+Action is what to do for the state stack of Lexer.
+
+State is the name of new state for StatePush and StateSwitch.
+It is ignored if Action is StatePop.
+
+State will changed by Lexer.Scan, not by Lexer.Peek.
+*/
+type StateTokenType struct {
+	TokenType
+	Action StateAction
+	State  string
+}
+
+/*
+Make new StateTokenType.
+
+tokenType is a TokenType for finding a token.
+
+action is StatePush, StatePop or StateSwitch.
+
+state is a name of new state. It is ignored if action is StatePop.
+*/
+func NewStateTokenType(tokenType TokenType, action StateAction, state string) *StateTokenType {
+	return &StateTokenType{
+		TokenType: tokenType,
+		Action:    action,
+		State:     state,
+	}
+}
+
+// Get readable string of TokenType and action.
+func (stt *StateTokenType) String() string {
+	s := typeString(stt.TokenType)
+
+	if stt.Action == StatePop {
+		return s + "(" + stt.Action.String() + ")"
+	}
+	return s + "(" + stt.Action.String() + " " + stt.State + ")"
+}
+
+func (stt *StateTokenType) needsAllInput() bool {
+	return needsAllInput(stt.TokenType)
+}
+
+// FindToken returns new Token if s starts with this token.
+func (stt *StateTokenType) FindToken(s string, p Position) *Token {
+	t := stt.TokenType.FindToken(s, p)
+	if t != nil {
+		t.transition = stt
+	}
+	return t
+}
+
+func (l *Lexer) rules() (TokenType, []TokenType) {
+	name := stateOf(l.cur.stateStack)
+	if name == DefaultState {
+		return l.Whitespace, l.TokenTypes
+	}
+
+	s := l.States[name]
+	if s == nil {
+		return nil, nil
+	}
+	return s.Whitespace, s.TokenTypes
+}
+
+func (l *Lexer) checkState(name string) error {
+	if name == DefaultState {
+		return nil
+	}
+	if _, ok := l.States[name]; !ok {
+		return UnknownStateError{
+			State:    name,
+			Position: l.cur.pos,
+		}
+	}
+	return nil
+}
+
+func (l *Lexer) checkTransition(stt *StateTokenType) error {
+	switch stt.Action {
+	case StatePush, StateSwitch:
+		return l.checkState(stt.State)
+	case StatePop:
+		if len(l.cur.stateStack) <= 1 {
+			return StateStackError{Position: l.cur.pos}
+		}
+	}
+	return nil
+}
+
+func (l *Lexer) applyTransition(stt *StateTokenType) {
+	stack := l.cur.stateStack
+	if len(stack) == 0 {
+		stack = []string{DefaultState}
+	}
+
+	// Make a new slice every time, because the old stack may be shared with lookahead tokens.
+	switch stt.Action {
+	case StatePush:
+		l.cur.stateStack = append(stack[:len(stack):len(stack)], stt.State)
+	case StatePop:
+		l.cur.stateStack = stack[:len(stack)-1]
+	case StateSwitch:
+		l.cur.stateStack = append(stack[:len(stack)-1:len(stack)-1], stt.State)
+	}
+}
+
+func stateOf(stack []string) string {
+	if len(stack) == 0 {
+		return DefaultState
+	}
+	return stack[len(stack)-1]
+}
+
+// State returns the name of the current state.
+func (l *Lexer) State() string {
+	return stateOf(l.consumer().stateStack)
+}
+
+/*
+StateStack returns a copy of the state stack.
+
+The first element is the bottom of the stack, and the last element is the current state.
+The bottom is DefaultState unless it was replaced by StateSwitch.
+*/
+func (l *Lexer) StateStack() []string {
+	stack := l.consumer().stateStack
+	if len(stack) == 0 {
+		return []string{DefaultState}
+	}
+	return append([]string(nil), stack...)
+}
+
+func (l *Lexer) changeState(stt *StateTokenType) error {
+	l.rewind(l.consumer())
+
+	if err := l.checkTransition(stt); err != nil {
+		return err
+	}
+	l.applyTransition(stt)
+	return nil
+}
+
+/*
+PushState pushes new state into the state stack.
+
+Tokens that already looked ahead will be discarded, and will be found again with the new state.
+*/
+func (l *Lexer) PushState(name string) error {
+	return l.changeState(&StateTokenType{Action: StatePush, State: name})
+}
+
+/*
+PopState pops the current state from the state stack.
+
+Tokens that already looked ahead will be discarded, and will be found again with the new state.
+*/
+func (l *Lexer) PopState() error {
+	return l.changeState(&StateTokenType{Action: StatePop})
+}
+
+/*
+SwitchState replaces the current state with new state.
+
+Tokens that already looked ahead will be discarded, and will be found again with the new state.
+*/
+func (l *Lexer) SwitchState(name string) error {
+	return l.changeState(&StateTokenType{Action: StateSwitch, State: name})
+}
@@ -0,0 +1,161 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func Example_states() {
+	const (
+		TEXT simplexer.TokenID = iota
+		OPEN
+		CLOSE
+	)
+
+	lexer := simplexer.NewLexer(strings.NewReader("hello ${name}, you are ${age + 1} years old"))
+
+	lexer.Whitespace = nil
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewStateTokenType(
+			simplexer.NewPatternTokenType(OPEN, []string{"${"}),
+			simplexer.StatePush,
+			"EXPR",
+		),
+		simplexer.NewRegexpTokenType(TEXT, `([^$]|\$[^{])+`),
+	}
+
+	lexer.States = map[string]*simplexer.State{
+		"EXPR": {
+			Whitespace: simplexer.DefaultWhitespace,
+			TokenTypes: append([]simplexer.TokenType{
+				simplexer.NewStateTokenType(
+					simplexer.NewPatternTokenType(CLOSE, []string{"}"}),
+					simplexer.StatePop,
+					"",
+				),
+			}, simplexer.DefaultTokenTypes...),
+		},
+	}
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%-7s %#v\n", lexer.State(), token.Literal)
+	}
+
+	// Output:
+	// INITIAL "hello "
+	// EXPR    "${"
+	// EXPR    "name"
+	// INITIAL "}"
+	// INITIAL ", you are "
+	// EXPR    "${"
+	// EXPR    "age"
+	// EXPR    "+"
+	// EXPR    "1"
+	// INITIAL "}"
+	// INITIAL " years old"
+}
+
+func TestLexer_States(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("a [b [c] d] e"))
+
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewStateTokenType(simplexer.NewPatternTokenType(0, []string{"["}), simplexer.StatePush, "BRACKET"),
+		simplexer.NewRegexpTokenType(1, `[a-z]+`),
+	}
+	lexer.States = map[string]*simplexer.State{
+		"BRACKET": {
+			Whitespace: simplexer.DefaultWhitespace,
+			TokenTypes: []simplexer.TokenType{
+				simplexer.NewStateTokenType(simplexer.NewPatternTokenType(0, []string{"["}), simplexer.StatePush, "BRACKET"),
+				simplexer.NewStateTokenType(simplexer.NewPatternTokenType(2, []string{"]"}), simplexer.StatePop, ""),
+				simplexer.NewRegexpTokenType(3, `[a-z]+`),
+			},
+		},
+	}
+
+	wants := []struct {
+		ID    simplexer.TokenID
+		Stack []string
+	}{
+		{1, []string{"INITIAL"}},
+		{0, []string{"INITIAL", "BRACKET"}},
+		{3, []string{"INITIAL", "BRACKET"}},
+		{0, []string{"INITIAL", "BRACKET", "BRACKET"}},
+		{3, []string{"INITIAL", "BRACKET", "BRACKET"}},
+		{2, []string{"INITIAL", "BRACKET"}},
+		{3, []string{"INITIAL", "BRACKET"}},
+		{2, []string{"INITIAL"}},
+		{1, []string{"INITIAL"}},
+	}
+
+	for _, except := range wants {
+		token, err := lexer.Scan()
+		if err != nil {
+			t.Fatalf("failed scan: %s", err.Error())
+		}
+		if token == nil {
+			t.Fatalf("excepted token but got nil")
+		}
+
+		if token.Type.GetID() != except.ID {
+			t.Errorf("excepted type %s but got %s", except.ID, token.Type.GetID())
+		}
+		if stack := lexer.StateStack(); !reflect.DeepEqual(stack, except.Stack) {
+			t.Errorf("excepted state stack %#v but got %#v", except.Stack, stack)
+		}
+	}
+}
+
+func TestLexer_States_errors(t *testing.T) {
+	lexer := simplexer.NewLexer(strings.NewReader("] ["))
+
+	lexer.TokenTypes = []simplexer.TokenType{
+		simplexer.NewStateTokenType(simplexer.NewPatternTokenType(0, []string{"]"}), simplexer.StatePop, ""),
+		simplexer.NewStateTokenType(simplexer.NewPatternTokenType(1, []string{"["}), simplexer.StatePush, "UNDEFINED"),
+	}
+
+	if token, err := lexer.Peek(); err != nil || token == nil || token.Literal != "]" {
+		t.Fatalf("excepted \"]\" but got %#v and %#v", token, err)
+	}
+
+	if _, err := lexer.Scan(); err == nil {
+		t.Fatalf("excepted error but got nil")
+	} else if _, ok := err.(simplexer.StateStackError); !ok {
+		t.Fatalf("excepted StateStackError but got %#v", err)
+	}
+
+	lexer.TokenTypes = lexer.TokenTypes[1:]
+	lexer.Whitespace = simplexer.NewPatternTokenType(-1, []string{"]", " "})
+
+	if _, err := lexer.Scan(); err == nil {
+		t.Fatalf("excepted error but got nil")
+	} else if e, ok := err.(simplexer.UnknownStateError); !ok {
+		t.Fatalf("excepted UnknownStateError but got %#v", err)
+	} else if e.State != "UNDEFINED" || e.Position != (simplexer.Position{Line: 0, Column: 2}) {
+		t.Errorf("unexcepted error: %#v", e)
+	}
+
+	if err := lexer.PushState("UNDEFINED"); err == nil {
+		t.Errorf("excepted error but got nil")
+	}
+
+	if err := lexer.PopState(); err == nil {
+		t.Errorf("excepted error but got nil")
+	}
+
+	if err := lexer.SwitchState(simplexer.DefaultState); err != nil {
+		t.Errorf("failed to switch state: %s", err)
+	}
+}
@@ -0,0 +1,303 @@
+package simplexer
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EscapeSet is a set of backslash escape sequences in string literal.
+type EscapeSet int
+
+const (
+	// RawEscapes has no escape sequence. Backslash is just a character.
+	RawEscapes EscapeSet = iota
+
+	// CEscapes is escape sequences of C.
+	// \a \b \f \n \r \t \v \\ \' \" \? \ooo \xhh, and backslash-newline for line continuation.
+	CEscapes
+
+	// GoEscapes is escape sequences of Go.
+	// \a \b \f \n \r \t \v \\ \' \" \ooo \xhh \uhhhh \Uhhhhhhhh.
+	GoEscapes
+
+	// JSONEscapes is escape sequences of JSON.
+	// \" \\ \/ \b \f \n \r \t \uhhhh, and surrogate pairs of \uhhhh.
+	JSONEscapes
+)
+
+/*
+StringTokenType is a TokenType for string literals.
+
+ID is TokenID for this token type.
+
+Quotes is a set of quote characters, like `"'`.
+A string starts with one of them and ends with the same character.
+
+Escapes is an EscapeSet for backslash escape sequences.
+
+If MultiLine is false, a newline in a string is an error.
+
+Found Token has the decoded string as Token.Value, and the raw content between quotes as Token.Submatches[0].
+If the string has no end quote, the Token has UnterminatedStringError as Token.Err.
+If the string has an invalid escape sequence, the Token has InvalidEscapeError as Token.Err.
+*/
+type StringTokenType struct {
+	ID        TokenID
+	Quotes    string
+	Escapes   EscapeSet
+	MultiLine bool
+}
+
+/*
+Make new StringTokenType.
+
+id is a TokenID of new StringTokenType.
+
+quotes is a set of quote characters.
+
+escapes is an EscapeSet.
+
+New StringTokenType doesn't allow multi-line string. Please set MultiLine if you want.
+*/
+func NewStringTokenType(id TokenID, quotes string, escapes EscapeSet) *StringTokenType {
+	return &StringTokenType{
+		ID:      id,
+		Quotes:  quotes,
+		Escapes: escapes,
+	}
+}
+
+// Get readable string of TokenID.
+func (stt *StringTokenType) String() string {
+	return stt.ID.String()
+}
+
+// GetID returns id of this token type.
+func (stt *StringTokenType) GetID() TokenID {
+	return stt.ID
+}
+
+// FindToken returns new Token if s starts with a string literal.
+func (stt *StringTokenType) FindToken(s string, p Position) *Token {
+	quote, size := utf8.DecodeRuneInString(s)
+	if size == 0 || quote == utf8.RuneError || !strings.ContainsRune(stt.Quotes, quote) {
+		return nil
+	}
+
+	var value strings.Builder
+	var escapeErr error
+
+	for i := size; i < len(s); {
+		c, n := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case c == quote:
+			t := &Token{
+				Type:       stt,
+				Literal:    s[:i+n],
+				Submatches: []string{s[size:i]},
+				Position:   p,
+				Value:      value.String(),
+			}
+			if escapeErr != nil {
+				t.Err = escapeErr
+			}
+			return t
+		case c == '\n' && !stt.MultiLine:
+			return stt.unterminated(s[:i], p)
+		case c == '\\' && stt.Escapes != RawEscapes:
+			if strings.HasPrefix(s[i+1:], "\n") && stt.Escapes != CEscapes && !stt.MultiLine {
+				return stt.unterminated(s[:i+1], p)
+			}
+
+			decoded, length, ok := decodeEscape(s[i:], stt.Escapes)
+			if !ok {
+				if escapeErr == nil {
+					escapeErr = InvalidEscapeError{
+						Sequence: s[i : i+length],
+						Position: shiftPos(p, s[:i]),
+					}
+				}
+			} else if s[i+1] == '\n' {
+				// Line continuation of C. It is just removed.
+			} else if decoded >= 0 {
+				value.WriteRune(decoded)
+			} else {
+				value.WriteByte(byte(-decoded - 1))
+			}
+			i += length
+			continue
+		default:
+			value.WriteRune(c)
+		}
+
+		i += n
+	}
+
+	return stt.unterminated(s, p)
+}
+
+func (stt *StringTokenType) unterminated(literal string, p Position) *Token {
+	return &Token{
+		Type:     stt,
+		Literal:  literal,
+		Position: p,
+		Err:      UnterminatedStringError{Position: p},
+	}
+}
+
+func hexValue(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'F':
+		return int(c-'A') + 10
+	default:
+		return -1
+	}
+}
+
+func readHex(s string, min, max int) (int, int) {
+	v, n := 0, 0
+	for n < max && n < len(s) {
+		h := hexValue(s[n])
+		if h < 0 {
+			break
+		}
+		v = v*16 + h
+		n++
+	}
+	if n < min {
+		return -1, n
+	}
+	return v, n
+}
+
+func simpleEscape(c byte, escapes EscapeSet) (rune, bool) {
+	switch c {
+	case 'b':
+		return '\b', true
+	case 'f':
+		return '\f', true
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case '\\':
+		return '\\', true
+	case '"':
+		return '"', true
+	}
+
+	switch escapes {
+	case CEscapes, GoEscapes:
+		switch c {
+		case 'a':
+			return '\a', true
+		case 'v':
+			return '\v', true
+		case '\'':
+			return '\'', true
+		}
+	case JSONEscapes:
+		if c == '/' {
+			return '/', true
+		}
+	}
+
+	if escapes == CEscapes {
+		switch c {
+		case '?':
+			return '?', true
+		case '\n':
+			return '\n', true
+		}
+	}
+
+	return 0, false
+}
+
+/*
+decodeEscape decodes an escape sequence at the head of s.
+
+Returns the decoded rune, length of the sequence, and whether it is valid.
+A byte escape like \xff that is not a valid rune is returned as -(byte+1).
+Line continuation of C is returned as '\n' with backslash-newline.
+*/
+func decodeEscape(s string, escapes EscapeSet) (rune, int, bool) {
+	if len(s) < 2 {
+		return 0, len(s), false
+	}
+
+	c := s[1]
+
+	if r, ok := simpleEscape(c, escapes); ok {
+		return r, 2, true
+	}
+
+	switch {
+	case escapes != JSONEscapes && '0' <= c && c <= '7':
+		max := 3
+		if len(s)-1 < max {
+			max = len(s) - 1
+		}
+
+		v, n := 0, 0
+		for n < max && '0' <= s[1+n] && s[1+n] <= '7' {
+			v = v*8 + int(s[1+n]-'0')
+			n++
+		}
+		if (escapes == GoEscapes && n != 3) || v > 0xFF {
+			return 0, 1 + n, false
+		}
+		return byteRune(v), 1 + n, true
+	case escapes != JSONEscapes && c == 'x':
+		v, n := readHex(s[2:], 2, 2)
+		if v < 0 {
+			return 0, 2 + n, false
+		}
+		return byteRune(v), 2 + n, true
+	case c == 'u' || (escapes == GoEscapes && c == 'U'):
+		digits := 4
+		if c == 'U' {
+			digits = 8
+		}
+
+		v, n := readHex(s[2:], digits, digits)
+		if v < 0 || !utf8.ValidRune(rune(v)) && !utf16.IsSurrogate(rune(v)) {
+			return 0, 2 + n, false
+		}
+
+		if escapes == JSONEscapes && utf16.IsSurrogate(rune(v)) {
+			if len(s) >= 12 && s[6] == '\\' && s[7] == 'u' {
+				if v2, _ := readHex(s[8:], 4, 4); v2 >= 0 {
+					if r := utf16.DecodeRune(rune(v), rune(v2)); r != utf8.RuneError {
+						return r, 12, true
+					}
+				}
+			}
+			return 0, 6, false
+		}
+
+		if utf16.IsSurrogate(rune(v)) {
+			return 0, 2 + n, false
+		}
+		return rune(v), 2 + n, true
+	}
+
+	_, size := utf8.DecodeRuneInString(s[1:])
+	return 0, 1 + size, false
+}
+
+// byteRune converts a byte value of escape sequence to a rune for decodeEscape.
+func byteRune(v int) rune {
+	if v < utf8.RuneSelf {
+		return rune(v)
+	}
+	return rune(-v - 1)
+}
@@ -0,0 +1,216 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewStringTokenType() {
+	lexer := simplexer.NewLexer(strings.NewReader(`"hello\tworld" 'it\'s' "あ"`))
+
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewStringTokenType(simplexer.STRING, `"'`, simplexer.GoEscapes),
+	}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%s -> %#v\n", token.Literal, token.Value)
+	}
+
+	// Output:
+	// "hello\tworld" -> "hello\tworld"
+	// 'it\'s' -> "it's"
+	// "あ" -> "あ"
+}
+
+func TestStringTokenType(t *testing.T) {
+	tests := []struct {
+		Type    *simplexer.StringTokenType
+		Input   string
+		Literal string
+		Value   string
+	}{
+		{simplexer.NewStringTokenType(0, `"`, simplexer.RawEscapes), `"a\"b"`, `"a\"`, `a\`},
+		{simplexer.NewStringTokenType(0, "`", simplexer.RawEscapes), "`a\\n`", "`a\\n`", `a\n`},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes), `"a\"b" c`, `"a\"b"`, `a"b`},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes), `"\x41\101é\U0001F600\\"`, `"\x41\101é\U0001F600\\"`, "AAé\U0001F600\\"},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes), `"\xff"`, `"\xff"`, "\xff"},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.CEscapes), `"\1\12\?\a" x`, `"\1\12\?\a"`, "\x01\n?\a"},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.CEscapes), "\"a\\\nb\"", "\"a\\\nb\"", "ab"},
+		{simplexer.NewStringTokenType(0, `"`, simplexer.JSONEscapes), `"\/😀A"`, `"\/😀A"`, "/\U0001F600A"},
+		{&simplexer.StringTokenType{Quotes: `"`, Escapes: simplexer.GoEscapes, MultiLine: true}, "\"a\nb\"", "\"a\nb\"", "a\nb"},
+		{simplexer.NewStringTokenType(0, `'"`, simplexer.GoEscapes), `'a"b'`, `'a"b'`, `a"b`},
+	}
+
+	pos := simplexer.Position{Line: 1, Column: 2}
+
+	for _, tc := range tests {
+		tok := tc.Type.FindToken(tc.Input, pos)
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Err != nil {
+			t.Errorf("%#v: unexcepted error: %s", tc.Input, tok.Err)
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Value != tc.Value {
+			t.Errorf("%#v: excepted value %#v but got %#v", tc.Input, tc.Value, tok.Value)
+		}
+		if len(tok.Submatches) != 1 || tok.Submatches[0] != tc.Literal[1:len(tc.Literal)-1] {
+			t.Errorf("%#v: excepted submatches %#v but got %#v", tc.Input, []string{tc.Literal[1 : len(tc.Literal)-1]}, tok.Submatches)
+		}
+		if tok.Position != pos {
+			t.Errorf("%#v: excepted position %s but got %s", tc.Input, pos, tok.Position)
+		}
+	}
+
+	if tok := simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes).FindToken(`a"b"`, pos); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+}
+
+func TestStringTokenType_errors(t *testing.T) {
+	tests := []struct {
+		Type    *simplexer.StringTokenType
+		Input   string
+		Literal string
+		Err     error
+	}{
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes),
+			`"abc`,
+			`"abc`,
+			simplexer.UnterminatedStringError{Position: simplexer.Position{Line: 1, Column: 2}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes),
+			"\"abc\ndef\"",
+			`"abc`,
+			simplexer.UnterminatedStringError{Position: simplexer.Position{Line: 1, Column: 2}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes),
+			"\"abc\\\ndef\"",
+			`"abc\`,
+			simplexer.UnterminatedStringError{Position: simplexer.Position{Line: 1, Column: 2}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes),
+			`"a\qb\zc" d`,
+			`"a\qb\zc"`,
+			simplexer.InvalidEscapeError{Sequence: `\q`, Position: simplexer.Position{Line: 1, Column: 4}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.GoEscapes),
+			`"\1"`,
+			`"\1"`,
+			simplexer.InvalidEscapeError{Sequence: `\1`, Position: simplexer.Position{Line: 1, Column: 3}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.JSONEscapes),
+			`"\ud83dx"`,
+			`"\ud83dx"`,
+			simplexer.InvalidEscapeError{Sequence: `\ud83d`, Position: simplexer.Position{Line: 1, Column: 3}},
+		},
+		{
+			simplexer.NewStringTokenType(0, `"`, simplexer.JSONEscapes),
+			`"\x41"`,
+			`"\x41"`,
+			simplexer.InvalidEscapeError{Sequence: `\x`, Position: simplexer.Position{Line: 1, Column: 3}},
+		},
+	}
+
+	pos := simplexer.Position{Line: 1, Column: 2}
+
+	for _, tc := range tests {
+		tok := tc.Type.FindToken(tc.Input, pos)
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Err != tc.Err {
+			t.Errorf("%#v: excepted error %#v but got %#v", tc.Input, tc.Err, tok.Err)
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+	}
+}
+
+func TestLexer_unterminatedString(t *testing.T) {
+	input := "a = \"" + strings.Repeat("x", 5000)
+
+	lexer := simplexer.NewLexer(strings.NewReader(input))
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewStringTokenType(simplexer.STRING, `"`, simplexer.GoEscapes),
+	}, lexer.TokenTypes...)
+
+	tokens, err := lexer.Tokens()
+	if s := literals(tokens); s != "a,=" {
+		t.Errorf("excepted \"a,=\" but got %#v", s)
+	}
+
+	except := simplexer.UnterminatedStringError{Position: simplexer.Position{Line: 0, Column: 4}}
+	if err != except {
+		t.Errorf("excepted %#v but got %#v", except, err)
+	}
+
+	lexer = simplexer.NewLexer(strings.NewReader(input + "\" b \"c\nd"))
+	lexer.Recovery = simplexer.EmitErrorToken
+	lexer.TokenTypes = append([]simplexer.TokenType{
+		simplexer.NewStringTokenType(simplexer.STRING, `"`, simplexer.GoEscapes),
+	}, lexer.TokenTypes...)
+
+	tokens, err = lexer.Tokens()
+	if err != nil {
+		t.Fatalf("failed scan: %s", err)
+	}
+
+	if len(tokens) != 6 {
+		t.Fatalf("excepted 6 tokens but got %v", tokens)
+	}
+	if len(tokens[2].Literal) != 5002 || tokens[2].Err != nil {
+		t.Errorf("excepted long string but got %d bytes and %#v", len(tokens[2].Literal), tokens[2].Err)
+	}
+	if tokens[4].Literal != "\"c" || tokens[4].Err == nil {
+		t.Errorf("excepted unterminated string but got %#v and %#v", tokens[4].Literal, tokens[4].Err)
+	}
+	if errs := lexer.Errors(); len(errs) != 1 || errs[0] != tokens[4].Err {
+		t.Errorf("excepted recorded error but got %#v", errs)
+	}
+}
+
+func TestLexer_invalidEscape_columns(t *testing.T) {
+	stt := simplexer.NewStringTokenType(simplexer.STRING, `"`, simplexer.GoEscapes)
+	stt.MultiLine = true
+
+	lexer := simplexer.NewLexer(strings.NewReader("値 = \"a\n\té\\q\""))
+	lexer.Columns = simplexer.AllColumns
+	lexer.TabWidth = 4
+	lexer.TokenTypes = append([]simplexer.TokenType{stt}, lexer.TokenTypes...)
+
+	_, err := lexer.Tokens()
+
+	except := simplexer.InvalidEscapeError{
+		Sequence: `\q`,
+		Position: simplexer.Position{Line: 1, Column: 3, RuneColumn: 2, UTF16Column: 2, DisplayColumn: 5},
+	}
+	if err != except {
+		t.Errorf("excepted %#v but got %#v", except, err)
+	}
+}
@@ -1,8 +1,8 @@
 package simplexer
 
 import (
+	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -15,26 +15,22 @@ const (
 	IDENT
 	NUMBER
 	STRING
+	ERROR
+	COMMENT
+	INDENT
+	DEDENT
+	NEWLINE
+	EOF
 )
 
 /*
 Convert to readable string.
 
-Be careful, user added token ID's will convert to UNKNOWN.
+The name is taken from DefaultRegistry.
+Be careful, user added token ID's will convert to UNKNOWN unless registered in DefaultRegistry.
 */
 func (id TokenID) String() string {
-	switch id {
-	case OTHER:
-		return "OTHER"
-	case IDENT:
-		return "IDENT"
-	case NUMBER:
-		return "NUMBER"
-	case STRING:
-		return "STRING"
-	default:
-		return "UNKNOWN(" + strconv.Itoa(int(id)) + ")"
-	}
+	return DefaultRegistry.String(id)
 }
 
 /*
@@ -45,6 +41,8 @@ TokenID can share with another TokenType.
 
 FindToken returns new Token if the head of first argument was matched with the pattern of this TokenType.
 The second argument is a position of the token in the buffer. In almost implement, Position will pass into result Token directly.
+If the head of first argument looks like this token but malformed, FindToken can return a Token with Err.
+Literal of the Token is the malformed span, and Lexer skips it in recovery mode.
 */
 type TokenType interface {
 	GetID() TokenID
@@ -71,12 +69,9 @@ id is a TokenID of new RegexpTokenType.
 re is a regular expression of token.
 */
 func NewRegexpTokenType(id TokenID, re string) *RegexpTokenType {
-	if !strings.HasPrefix(re, "^") {
-		re = "^(?:" + re + ")"
-	}
 	return &RegexpTokenType{
 		ID: id,
-		Re: regexp.MustCompile(re),
+		Re: regexp.MustCompile(anchorRegexp(re)),
 	}
 }
 
@@ -108,6 +103,8 @@ func (rtt *RegexpTokenType) FindToken(s string, p Position) *Token {
 PatternTokenType is dictionary token type.
 
 PatternTokenType has some strings and find token that perfect match they.
+Patterns are checked in order, so "=" before "==" will hide "==".
+Please use TrieTokenType if you want the longest pattern.
 */
 type PatternTokenType struct {
 	ID       TokenID
@@ -158,4 +155,35 @@ type Token struct {
 	Literal    string   // The string of matched.
 	Submatches []string // Submatches of regular expression.
 	Position   Position // Position of token.
+	End        Position // Position of the next character of token.
+	Offset     int      // Byte offset of token from the head of input.
+	EndOffset  int      // Byte offset of the next character of token.
+
+	// Value is the value of literal that decoded by TokenType, like string of StringTokenType.
+	// It is nil if TokenType does not decode literal.
+	Value interface{}
+
+	// Err is an error if the token is malformed, like UnterminatedStringError.
+	// Lexer returns it as an error, or records it in recovery mode.
+	Err error
+
+	// LeadingTrivia and TrailingTrivia are skipped tokens around this token, like whitespaces and comments.
+	// They are set only if Lexer.Lossless is true.
+	LeadingTrivia  []*Token
+	TrailingTrivia []*Token
+
+	transition *StateTokenType
+	trivia     bool
+}
+
+// Get readable string of Token.
+func (t *Token) String() string {
+	return fmt.Sprintf("%s(%#v)", typeString(t.Type), t.Literal)
+}
+
+func typeString(tt TokenType) string {
+	if s, ok := tt.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return tt.GetID().String()
 }
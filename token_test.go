@@ -159,3 +159,14 @@ func TestPatternTokenType(t *testing.T) {
 		}
 	}
 }
+
+func TestToken_String(t *testing.T) {
+	tok := simplexer.Token{
+		Type:    simplexer.NewRegexpTokenType(simplexer.IDENT, `[a-z]+`),
+		Literal: "hello",
+	}
+
+	if s := tok.String(); s != "IDENT(\"hello\")" {
+		t.Errorf("excepted %#v but got %#v", "IDENT(\"hello\")", s)
+	}
+}
@@ -0,0 +1,174 @@
+package simplexer
+
+import (
+	"sort"
+)
+
+type trieNode struct {
+	children map[byte]*trieNode
+	pattern  *TriePattern
+}
+
+/*
+TrieTokenType is a dictionary token type like PatternTokenType, but backed by a trie.
+
+TrieTokenType always finds the longest matched pattern regardless of the order of patterns.
+So "==" will be found even if "=" was added before it.
+And it does not scan patterns linearly, so it is fast even if there are hundreds of operators.
+
+Each pattern can have its own TokenID, and Token.Type of found token is a TriePattern.
+*/
+type TrieTokenType struct {
+	ID   TokenID
+	root trieNode
+	size int
+}
+
+/*
+TriePattern is a TokenType of a pattern in TrieTokenType.
+
+It is used as Token.Type of tokens that found by TrieTokenType.
+*/
+type TriePattern struct {
+	ID      TokenID
+	Pattern string
+	parent  *TrieTokenType
+}
+
+/*
+Make new TrieTokenType.
+
+id is a TokenID of new TrieTokenType, and it is used as TokenID of patterns.
+
+patterns is array of patterns.
+Patterns with different TokenID can be added by TrieTokenType.Add.
+*/
+func NewTrieTokenType(id TokenID, patterns []string) *TrieTokenType {
+	ttt := &TrieTokenType{ID: id}
+
+	for _, p := range patterns {
+		ttt.Add(p, id)
+	}
+
+	return ttt
+}
+
+/*
+Add adds a pattern with TokenID.
+
+If the pattern was already added, its TokenID will be replaced.
+*/
+func (ttt *TrieTokenType) Add(pattern string, id TokenID) {
+	node := &ttt.root
+
+	for i := 0; i < len(pattern); i++ {
+		if node.children == nil {
+			node.children = make(map[byte]*trieNode)
+		}
+
+		next, ok := node.children[pattern[i]]
+		if !ok {
+			next = &trieNode{}
+			node.children[pattern[i]] = next
+		}
+		node = next
+	}
+
+	if node.pattern == nil {
+		ttt.size++
+	}
+	node.pattern = &TriePattern{
+		ID:      id,
+		Pattern: pattern,
+		parent:  ttt,
+	}
+}
+
+// Patterns returns all patterns in the trie, in lexical order.
+func (ttt *TrieTokenType) Patterns() []*TriePattern {
+	ps := make([]*TriePattern, 0, ttt.size)
+
+	var walk func(n *trieNode)
+	walk = func(n *trieNode) {
+		if n.pattern != nil {
+			ps = append(ps, n.pattern)
+		}
+
+		keys := make([]int, 0, len(n.children))
+		for k := range n.children {
+			keys = append(keys, int(k))
+		}
+		sort.Ints(keys)
+
+		for _, k := range keys {
+			walk(n.children[byte(k)])
+		}
+	}
+	walk(&ttt.root)
+
+	return ps
+}
+
+// Get readable string of TokenID.
+func (ttt *TrieTokenType) String() string {
+	return ttt.ID.String()
+}
+
+// GetID returns id of token type.
+func (ttt *TrieTokenType) GetID() TokenID {
+	return ttt.ID
+}
+
+func (ttt *TrieTokenType) findPattern(s string) *TriePattern {
+	node := &ttt.root
+	found := node.pattern
+
+	for i := 0; i < len(s) && node.children != nil; i++ {
+		next, ok := node.children[s[i]]
+		if !ok {
+			break
+		}
+		node = next
+
+		if node.pattern != nil {
+			found = node.pattern
+		}
+	}
+
+	return found
+}
+
+// FindToken returns new Token if s starts with one of patterns. The longest pattern is used.
+func (ttt *TrieTokenType) FindToken(s string, p Position) *Token {
+	if tp := ttt.findPattern(s); tp != nil {
+		return &Token{
+			Type:     tp,
+			Literal:  tp.Pattern,
+			Position: p,
+		}
+	}
+	return nil
+}
+
+// Get readable string of TokenID.
+func (tp *TriePattern) String() string {
+	return tp.ID.String()
+}
+
+// GetID returns id of this pattern.
+func (tp *TriePattern) GetID() TokenID {
+	return tp.ID
+}
+
+// FindToken returns new Token if this pattern is the longest pattern that s starts with.
+func (tp *TriePattern) FindToken(s string, p Position) *Token {
+	if tp.parent.findPattern(s) != tp {
+		return nil
+	}
+
+	return &Token{
+		Type:     tp,
+		Literal:  tp.Pattern,
+		Position: p,
+	}
+}
@@ -0,0 +1,110 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleNewTrieTokenType() {
+	const (
+		OPERATOR simplexer.TokenID = iota
+		ARROW
+	)
+
+	lexer := simplexer.NewLexer(strings.NewReader("a = b == c => d"))
+
+	operators := simplexer.NewTrieTokenType(OPERATOR, []string{"=", "==", "<", "<="})
+	operators.Add("=>", ARROW)
+
+	lexer.TokenTypes = append([]simplexer.TokenType{operators}, lexer.TokenTypes...)
+
+	for {
+		token, _ := lexer.Scan()
+		if token == nil {
+			break
+		}
+
+		switch token.Type.GetID() {
+		case OPERATOR:
+			fmt.Printf("operator %s\n", token.Literal)
+		case ARROW:
+			fmt.Printf("arrow %s\n", token.Literal)
+		default:
+			fmt.Println(token.Literal)
+		}
+	}
+
+	// Output:
+	// a
+	// operator =
+	// b
+	// operator ==
+	// c
+	// arrow =>
+	// d
+}
+
+func TestTrieTokenType(t *testing.T) {
+	tt := simplexer.NewTrieTokenType(1, []string{"<", "<<", "<<=", "<=", "-"})
+	tt.Add("->", 2)
+	tt.Add("-", 3)
+
+	tests := []struct {
+		Input   string
+		ID      simplexer.TokenID
+		Literal string
+	}{
+		{"<<= 1", 1, "<<="},
+		{"<<1", 1, "<<"},
+		{"<=", 1, "<="},
+		{"<-", 1, "<"},
+		{"->x", 2, "->"},
+		{"-x", 3, "-"},
+	}
+
+	pos := simplexer.Position{Line: 1, Column: 2}
+
+	for _, tc := range tests {
+		tok := tt.FindToken(tc.Input, pos)
+		if tok == nil {
+			t.Errorf("%#v: excepted token but got nil", tc.Input)
+			continue
+		}
+
+		if tok.Type.GetID() != tc.ID {
+			t.Errorf("%#v: excepted type %s but got %s", tc.Input, tc.ID, tok.Type.GetID())
+		}
+		if tok.Literal != tc.Literal {
+			t.Errorf("%#v: excepted literal %#v but got %#v", tc.Input, tc.Literal, tok.Literal)
+		}
+		if tok.Position != pos {
+			t.Errorf("%#v: excepted position %s but got %s", tc.Input, pos, tok.Position)
+		}
+		if tok.Type.FindToken(tc.Input, pos) == nil {
+			t.Errorf("%#v: TriePattern %s did not match", tc.Input, tok.Type)
+		}
+	}
+
+	if tok := tt.FindToken("x<", pos); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+	if tok := tt.FindToken("", pos); tok != nil {
+		t.Errorf("excepted nil but got %s", tok)
+	}
+
+	var patterns []string
+	for _, p := range tt.Patterns() {
+		patterns = append(patterns, p.Pattern)
+	}
+	if s := strings.Join(patterns, " "); s != "- -> < << <<= <=" {
+		t.Errorf("excepted patterns \"- -> < << <<= <=\" but got %#v", s)
+	}
+
+	lt := tt.Patterns()[2]
+	if lt.FindToken("<<", pos) != nil {
+		t.Errorf("TriePattern %#v matched to prefix of longer pattern", lt.Pattern)
+	}
+}
@@ -0,0 +1,86 @@
+package simplexer
+
+import (
+	"strings"
+)
+
+// skip consumes t as trivia, and appends it to trivia if Lexer.Lossless is true.
+func (l *Lexer) skip(t *Token, trivia *[]*Token) {
+	if l.Lossless {
+		l.setSpan(t)
+		*trivia = append(*trivia, t)
+	}
+	l.consume(t)
+}
+
+// nextTrivia consumes a whitespace, a trivia comment, or a newline of blank line at the cursor. Returns nil if there is no trivia.
+func (l *Lexer) nextTrivia() *Token {
+	if err := l.readBufIfNeed(); err != nil {
+		return nil
+	}
+
+	if l.newlineSignificant() && strings.HasPrefix(l.rest(), "\n") {
+		if l.cur.indent.hasToken {
+			return nil
+		}
+
+		l.cur.indent.midLine = false
+		t := &Token{Type: NewlineTokenType, Literal: "\n", Position: l.cur.pos}
+		l.setSpan(t)
+		l.consume(t)
+		return t
+	}
+
+	if t := l.findWhitespace(); t != nil && t.Literal != "" {
+		l.setSpan(t)
+		l.consume(t)
+		return t
+	}
+
+	t, err := l.findLongToken()
+	if err != nil || t == nil || t.Err != nil || !t.trivia || t.Literal == "" {
+		return nil
+	}
+
+	l.setSpan(t)
+	l.consume(t)
+	return t
+}
+
+/*
+attachTrivia sets leading trivia of t, and reads trailing trivia of t if Lexer.Lossless is true.
+
+Trailing trivia is trivia after t until the end of line, including the newline.
+If there is only trivia until the end of input, all of them are trailing trivia of t.
+*/
+func (l *Lexer) attachTrivia(t *Token, leading []*Token) {
+	if !l.Lossless {
+		return
+	}
+
+	t.LeadingTrivia = leading
+
+	var trivia []*Token
+	lineEnd := -1
+	var afterLine cursor
+
+	for {
+		piece := l.nextTrivia()
+		if piece == nil {
+			break
+		}
+
+		trivia = append(trivia, piece)
+		if lineEnd < 0 && strings.Contains(piece.Literal, "\n") {
+			lineEnd = len(trivia)
+			afterLine = l.cur
+		}
+	}
+
+	if lineEnd >= 0 && (!l.eof || len(l.rest()) > 0) {
+		trivia = trivia[:lineEnd]
+		l.cur = afterLine
+	}
+
+	t.TrailingTrivia = trivia
+}
@@ -0,0 +1,237 @@
+package simplexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/macrat/simplexer"
+)
+
+func ExampleLexer_lossless() {
+	lexer := simplexer.NewLexer(strings.NewReader("a = 1 // one\n  b = 2\n"))
+	lexer.Lossless = true
+
+	comment := simplexer.NewLineCommentTokenType(simplexer.COMMENT, "//")
+	comment.Trivia = true
+	lexer.TokenTypes = append([]simplexer.TokenType{comment}, lexer.TokenTypes...)
+
+	for {
+		token, err := lexer.Scan()
+		if err != nil {
+			panic(err.Error())
+		}
+		if token == nil {
+			break
+		}
+
+		fmt.Printf("%#v %#v %#v\n", joinTrivia(token.LeadingTrivia), token.Literal, joinTrivia(token.TrailingTrivia))
+	}
+
+	// Output:
+	// "" "a" " "
+	// "" "=" " "
+	// "" "1" " // one\n"
+	// "  " "b" " "
+	// "" "=" " "
+	// "" "2" "\n"
+}
+
+func joinTrivia(trivia []*simplexer.Token) string {
+	var s string
+	for _, t := range trivia {
+		s += t.Literal
+	}
+	return s
+}
+
+func reproduce(tokens []*simplexer.Token) string {
+	var s string
+	for _, t := range tokens {
+		s += joinTrivia(t.LeadingTrivia) + t.Literal + joinTrivia(t.TrailingTrivia)
+	}
+	return s
+}
+
+func losslessLexer(input string, recovery simplexer.RecoveryMode) *simplexer.Lexer {
+	lexer := simplexer.NewLexer(iotest.OneByteReader(strings.NewReader(input)))
+	lexer.Lossless = true
+	lexer.Recovery = recovery
+
+	line := simplexer.NewLineCommentTokenType(simplexer.COMMENT, "#")
+	line.Trivia = true
+	block := simplexer.NewBlockCommentTokenType(simplexer.COMMENT, "/*", "*/", true)
+	block.Trivia = true
+
+	lexer.TokenTypes = []simplexer.TokenType{
+		line,
+		block,
+		simplexer.NewStringTokenType(simplexer.STRING, `"`, simplexer.GoEscapes),
+		simplexer.NewRegexpTokenType(simplexer.IDENT, `[a-z]+`),
+		simplexer.NewPatternTokenType(simplexer.OTHER, []string{"=", "(", ")"}),
+	}
+
+	return lexer
+}
+
+func TestLexer_losslessRoundTrip(t *testing.T) {
+	inputs := []string{
+		"",
+		"a",
+		"  a  ",
+		"\n\na\n\n",
+		"a = b # comment\n\n  c(d) /* x\ny /* z */ */ e\n",
+		"a\t\t# only comment at the end\n# and more\n\n",
+		"/* leading */ a /* trailing */",
+		"a = \"b\\n c\" # tail",
+		"a =" + strings.Repeat(" ", 3000) + "b" + strings.Repeat("\n", 3000),
+		"   ",
+		"\n\n",
+		"# only comment",
+		" /* a */\n# b\n",
+	}
+
+	for _, input := range inputs {
+		tokens, err := losslessLexer(input, simplexer.NoRecovery).Tokens()
+		if err != nil {
+			t.Errorf("%#v: failed to scan: %s", input, err)
+			continue
+		}
+
+		if output := reproduce(tokens); output != input {
+			t.Errorf("%#v: excepted the same string but got %#v", input, output)
+		}
+	}
+}
+
+func TestLexer_losslessRecovery(t *testing.T) {
+	input := "a ! b\n?? c \"d\ne"
+
+	tokens, err := losslessLexer(input, simplexer.SkipError).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	if s := literals(tokens); s != "a,b,c,e" {
+		t.Errorf("excepted \"a,b,c,e\" but got %#v", s)
+	}
+	if output := reproduce(tokens); output != input {
+		t.Errorf("excepted the same string but got %#v", output)
+	}
+
+	tokens, err = losslessLexer(input, simplexer.EmitErrorToken).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	if s := literals(tokens); s != "a,!,b,??,c,\"d,e" {
+		t.Errorf("excepted \"a,!,b,??,c,\\\"d,e\" but got %#v", s)
+	}
+	if output := reproduce(tokens); output != input {
+		t.Errorf("excepted the same string but got %#v", output)
+	}
+}
+
+func TestLexer_losslessTriviaPosition(t *testing.T) {
+	tokens, err := losslessLexer("a # x\n b", simplexer.NoRecovery).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	trailing := tokens[0].TrailingTrivia
+	if len(trailing) != 3 {
+		t.Fatalf("excepted 3 trivia but got %v", trailing)
+	}
+	if trailing[1].Type.GetID() != simplexer.COMMENT || trailing[1].Offset != 2 || trailing[1].EndOffset != 5 {
+		t.Errorf("excepted comment at 2-5 but got %s at %d-%d", trailing[1], trailing[1].Offset, trailing[1].EndOffset)
+	}
+
+	leading := tokens[1].LeadingTrivia
+	except := simplexer.Position{Line: 1, Column: 0}
+	if len(leading) != 1 || leading[0].Position != except {
+		t.Errorf("excepted a trivia at %s but got %v", except, leading)
+	}
+
+	tokens, err = simplexer.NewLexer(strings.NewReader("a b")).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+	if tokens[0].TrailingTrivia != nil || tokens[1].LeadingTrivia != nil {
+		t.Errorf("excepted no trivia without Lossless but got %v and %v", tokens[0].TrailingTrivia, tokens[1].LeadingTrivia)
+	}
+}
+
+func TestLexer_losslessEOF(t *testing.T) {
+	tokens, err := losslessLexer(" # x\n", simplexer.NoRecovery).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+
+	if len(tokens) != 1 {
+		t.Fatalf("excepted 1 token but got %v", tokens)
+	}
+	if tokens[0].Type.GetID() != simplexer.EOF || tokens[0].Literal != "" {
+		t.Errorf("excepted empty EOF token but got %s", tokens[0])
+	}
+	if s := joinTrivia(tokens[0].LeadingTrivia); s != " # x\n" {
+		t.Errorf("excepted leading trivia \" # x\\n\" but got %#v", s)
+	}
+	if except := (simplexer.Position{Line: 1, Column: 0}); tokens[0].Position != except {
+		t.Errorf("excepted position %s but got %s", except, tokens[0].Position)
+	}
+
+	tokens, err = losslessLexer("a ", simplexer.NoRecovery).Tokens()
+	if err != nil {
+		t.Fatalf("failed to scan: %s", err)
+	}
+	if s := literals(tokens); s != "a" {
+		t.Errorf("excepted no EOF token after the last token but got %#v", s)
+	}
+}
+
+func TestLexer_losslessStateRetry(t *testing.T) {
+	tests := []struct {
+		Input    string
+		Recovery simplexer.RecoveryMode
+		Errors   int
+	}{
+		{"a = b # x\n  /* y */ ]\nc\n", simplexer.NoRecovery, 0},
+		{"a ] b", simplexer.NoRecovery, 0},
+		{"] ]", simplexer.NoRecovery, 0},
+		{"a ! ] b", simplexer.SkipError, 1},
+	}
+
+	for _, tc := range tests {
+		lexer := losslessLexer(tc.Input, tc.Recovery)
+		lexer.TokenTypes = append([]simplexer.TokenType{
+			simplexer.NewStateTokenType(simplexer.NewPatternTokenType(simplexer.OTHER, []string{"]"}), simplexer.StatePop, ""),
+		}, lexer.TokenTypes...)
+
+		var tokens []*simplexer.Token
+		for retried := 0; ; {
+			token, err := lexer.Scan()
+			if _, ok := err.(simplexer.StateStackError); ok && retried < 10 {
+				retried++
+				if err := lexer.PushState(simplexer.DefaultState); err != nil {
+					t.Fatalf("%#v: failed to push state: %s", tc.Input, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%#v: failed to scan: %s", tc.Input, err)
+			}
+			if token == nil {
+				break
+			}
+			tokens = append(tokens, token)
+		}
+
+		if output := reproduce(tokens); output != tc.Input {
+			t.Errorf("%#v: excepted the same string but got %#v", tc.Input, output)
+		}
+		if errs := lexer.Errors(); len(errs) != tc.Errors {
+			t.Errorf("%#v: excepted %d errors but got %v", tc.Input, tc.Errors, errs)
+		}
+	}
+}